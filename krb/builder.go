@@ -0,0 +1,328 @@
+package krb
+
+// DocumentBuilder assembles a *Document in memory, for tests and
+// programmatic UI generation that don't want to go through an external
+// .krb compiler. Build the tree with AddElement/ElementBuilder.AddChild,
+// intern strings with String, define styles with AddStyle and component
+// templates with AddComponentDef, then call Build. The result is an
+// ordinary *Document, ready for render.PrepareTree exactly like one
+// DecodeBytes returned -- there's no binary encoder in this package
+// (nothing yet writes a Document back out to .krb bytes), so Build only
+// produces the in-memory tree, not a file.
+type DocumentBuilder struct {
+	doc         *Document
+	stringIndex map[string]uint8
+	nextStyleID uint8
+}
+
+// NewDocumentBuilder returns an empty DocumentBuilder. Strings[0] is
+// seeded as "" up front, matching the convention decoded documents
+// conventionally follow (see Document.String), so index 0 keeps meaning
+// "no string" in a builder-produced document too.
+func NewDocumentBuilder() *DocumentBuilder {
+	return &DocumentBuilder{
+		doc:         &Document{Strings: []string{""}},
+		stringIndex: map[string]uint8{"": 0},
+	}
+}
+
+// String interns s into the document's string table if it isn't already
+// there and returns its index. Every other builder method that takes a
+// name (an element ID, a callback name, a custom property key, ...)
+// calls this internally, so assembling a document by hand rarely needs
+// it directly -- it's exported for the cases that do, like resolving an
+// external resource's path to the DataIndex Resource expects.
+func (b *DocumentBuilder) String(s string) uint8 {
+	if idx, ok := b.stringIndex[s]; ok {
+		return idx
+	}
+	idx := uint8(len(b.doc.Strings))
+	b.doc.Strings = append(b.doc.Strings, s)
+	b.stringIndex[s] = idx
+	return idx
+}
+
+// AddStyle appends a new, empty style named name and returns a builder
+// for it. Style IDs are assigned sequentially starting at 1, matching
+// Element.StyleID's "0 means no style" convention.
+func (b *DocumentBuilder) AddStyle(name string) *StyleBuilder {
+	b.nextStyleID++
+	b.doc.Styles = append(b.doc.Styles, Style{ID: b.nextStyleID, NameIndex: b.String(name)})
+	return &StyleBuilder{b: b, index: len(b.doc.Styles) - 1}
+}
+
+// AddResource appends a resource holding inline data and returns its
+// index (for a KeyIndex/DataIndex field elsewhere that references it).
+func (b *DocumentBuilder) AddResource(resourceType, format uint8, data []byte) uint8 {
+	index := uint8(len(b.doc.Resources))
+	b.doc.Resources = append(b.doc.Resources, Resource{Type: resourceType, Format: format, Data: data})
+	return index
+}
+
+// AddExternalResource appends a resource referencing an external path
+// (interned into the string table as DataIndex) rather than inline Data,
+// and returns its index.
+func (b *DocumentBuilder) AddExternalResource(resourceType uint8, path string) uint8 {
+	index := uint8(len(b.doc.Resources))
+	b.doc.Resources = append(b.doc.Resources, Resource{Type: resourceType, DataIndex: b.String(path)})
+	return index
+}
+
+// AddElement appends a new root-level element of the given type and
+// returns a builder for it. Use ElementBuilder.AddChild to nest further
+// elements underneath, e.g.:
+//
+//	b := krb.NewDocumentBuilder()
+//	root := b.AddElement(krb.ElemTypeApp)
+//	root.AddChild(krb.ElemTypeText).SetBounds(0, 0, 100, 20)
+//	doc := b.Build()
+func (b *DocumentBuilder) AddElement(elementType uint8) *ElementBuilder {
+	el := &Element{Index: len(b.doc.Elements), Type: elementType}
+	b.doc.Elements = append(b.doc.Elements, el)
+	return &ElementBuilder{b: b, el: el}
+}
+
+// AddComponentDef starts a new component template named name and
+// returns a builder for it. Call Root to give the template a root
+// element before adding property defaults or expanding an instance of
+// it, the same way a real .krb file's Component Definition Table always
+// pairs a definition with at least a root element.
+func (b *DocumentBuilder) AddComponentDef(name string) *ComponentDefBuilder {
+	b.doc.ComponentDefs = append(b.doc.ComponentDefs, ComponentDef{NameIndex: b.String(name)})
+	return &ComponentDefBuilder{b: b, index: len(b.doc.ComponentDefs) - 1}
+}
+
+// Build finalizes and returns the assembled *Document, filling in
+// Header.Flags and the element-count fields other code consults (see
+// Header.HasFlag) as if the document had been decoded from a real .krb
+// file with those features present. It's safe to keep calling other
+// DocumentBuilder methods and call Build again afterward -- each call
+// recomputes the header from the builder's current state rather than
+// consuming it.
+func (b *DocumentBuilder) Build() *Document {
+	h := &b.doc.Header
+	h.Flags = 0
+	if len(b.doc.Styles) > 0 {
+		h.Flags |= FlagHasStyles
+	}
+	if len(b.doc.ComponentDefs) > 0 {
+		h.Flags |= FlagHasComponentDefs
+	}
+	if len(b.doc.Animations) > 0 {
+		h.Flags |= FlagHasAnimations
+	}
+	if len(b.doc.Resources) > 0 {
+		h.Flags |= FlagHasResources
+	}
+	for _, el := range b.doc.Elements {
+		if el.Type == ElemTypeApp {
+			h.Flags |= FlagHasApp
+			break
+		}
+	}
+	h.ElementCount = uint16(len(b.doc.Elements))
+	h.StyleCount = uint16(len(b.doc.Styles))
+	h.ComponentDefCount = uint16(len(b.doc.ComponentDefs))
+	h.AnimationCount = uint16(len(b.doc.Animations))
+	h.StringCount = uint16(len(b.doc.Strings))
+	h.ResourceCount = uint16(len(b.doc.Resources))
+	return b.doc
+}
+
+// StyleBuilder wraps a Style under construction. It's always obtained
+// from DocumentBuilder.AddStyle, never constructed directly.
+type StyleBuilder struct {
+	b     *DocumentBuilder
+	index int
+}
+
+// ID returns the style's assigned ID, for passing to
+// ElementBuilder.SetStyle.
+func (sb *StyleBuilder) ID() uint8 {
+	return sb.b.doc.Styles[sb.index].ID
+}
+
+// AddProperty appends a standard property to the style and returns sb
+// for chaining.
+func (sb *StyleBuilder) AddProperty(id, valueType uint8, value []byte) *StyleBuilder {
+	sb.b.doc.Styles[sb.index].Properties = append(sb.b.doc.Styles[sb.index].Properties,
+		Property{ID: id, Type: valueType, Size: uint8(len(value)), Value: value})
+	return sb
+}
+
+// ElementBuilder wraps an Element under construction in the main
+// document tree, returned by DocumentBuilder.AddElement or
+// ElementBuilder.AddChild so calls can be chained.
+type ElementBuilder struct {
+	b  *DocumentBuilder
+	el *Element
+}
+
+// Element returns the underlying *Element, e.g. to read back its
+// assigned Index once other elements need to reference it.
+func (eb *ElementBuilder) Element() *Element { return eb.el }
+
+// AddChild appends a new element of the given type as the last child of
+// eb's element and returns a builder for it.
+func (eb *ElementBuilder) AddChild(elementType uint8) *ElementBuilder {
+	child := eb.b.AddElement(elementType)
+	eb.el.Children = append(eb.el.Children, child.el.Index)
+	return child
+}
+
+// SetID names eb's element, resolvable later via Document.ElementIDName.
+func (eb *ElementBuilder) SetID(name string) *ElementBuilder {
+	eb.el.ID = eb.b.String(name)
+	return eb
+}
+
+// SetBounds sets eb's element's declared position and size.
+func (eb *ElementBuilder) SetBounds(x, y, width, height uint16) *ElementBuilder {
+	eb.el.PosX, eb.el.PosY, eb.el.Width, eb.el.Height = x, y, width, height
+	return eb
+}
+
+// SetLayout sets eb's element's layout byte (direction/alignment/wrap
+// bits; see the krb.Layout* constants).
+func (eb *ElementBuilder) SetLayout(layout uint8) *ElementBuilder {
+	eb.el.Layout = layout
+	return eb
+}
+
+// SetStyle attaches a style by ID, e.g. from StyleBuilder.ID.
+func (eb *ElementBuilder) SetStyle(styleID uint8) *ElementBuilder {
+	eb.el.StyleID = styleID
+	return eb
+}
+
+// AddProperty appends a standard property to eb's element.
+func (eb *ElementBuilder) AddProperty(id, valueType uint8, value []byte) *ElementBuilder {
+	eb.el.Properties = append(eb.el.Properties, Property{ID: id, Type: valueType, Size: uint8(len(value)), Value: value})
+	return eb
+}
+
+// AddCustomProperty appends a custom property to eb's element, interning
+// key into the string table.
+func (eb *ElementBuilder) AddCustomProperty(key string, valueType uint8, value []byte) *ElementBuilder {
+	eb.el.CustomProperties = append(eb.el.CustomProperties,
+		CustomProperty{KeyIndex: eb.b.String(key), Type: valueType, Size: uint8(len(value)), Value: value})
+	return eb
+}
+
+// AddEvent binds eventType to callbackName, interned into the string
+// table and resolved by name at dispatch time via render.RegisterHandler.
+func (eb *ElementBuilder) AddEvent(eventType uint8, callbackName string) *ElementBuilder {
+	eb.el.Events = append(eb.el.Events, Event{Type: eventType, CallbackID: eb.b.String(callbackName)})
+	return eb
+}
+
+// componentNameKey is the conventional custom property key a compiler
+// emits on a placeholder element to mark it as a component instance; see
+// krb_source_spec.md section 9, "Runtime Interpretation: Component
+// Instantiation". render's component expansion is what interprets it.
+const componentNameKey = "_componentName"
+
+// AddComponentInstance appends a new placeholder element for an instance
+// of the named component template (see DocumentBuilder.AddComponentDef)
+// as the last child of eb's element, and returns a builder for it. Set
+// further custom properties on the returned builder to override the
+// template's declared PropertyDefs for this instance.
+func (eb *ElementBuilder) AddComponentInstance(componentName string) *ElementBuilder {
+	instance := eb.AddChild(ElemTypeContainer)
+	instance.AddCustomProperty(componentNameKey, ValTypeString, []byte{eb.b.String(componentName)})
+	return instance
+}
+
+// ComponentDefBuilder wraps a ComponentDef under construction, returned
+// by DocumentBuilder.AddComponentDef.
+type ComponentDefBuilder struct {
+	b     *DocumentBuilder
+	index int
+}
+
+// AddPropertyDef declares a property instances of this component may
+// override; a placeholder's own custom properties supply the value at
+// expansion time, falling back to defaultValue when it doesn't.
+func (cb *ComponentDefBuilder) AddPropertyDef(name string, valueTypeHint uint8, defaultValue []byte) *ComponentDefBuilder {
+	def := &cb.b.doc.ComponentDefs[cb.index]
+	def.PropertyDefs = append(def.PropertyDefs, PropertyDef{
+		NameIndex:     cb.b.String(name),
+		ValueTypeHint: valueTypeHint,
+		DefaultValue:  defaultValue,
+	})
+	return cb
+}
+
+// Root gives the component template a root element of the given type
+// and returns a builder for it. Calling Root again replaces whatever
+// root (and template tree under it) was set before.
+func (cb *ComponentDefBuilder) Root(elementType uint8) *TemplateElementBuilder {
+	def := &cb.b.doc.ComponentDefs[cb.index]
+	root := &Element{Index: 0, Type: elementType}
+	def.Elements = []*Element{root}
+	def.Root = root
+	return &TemplateElementBuilder{cb: cb, el: root}
+}
+
+// TemplateElementBuilder wraps an Element under construction inside a
+// component template's own scoped tree (ComponentDef.Elements), returned
+// by ComponentDefBuilder.Root or TemplateElementBuilder.AddChild. Its
+// Children indices are scoped to that template, never into the main
+// document's Elements, even though it shares Element's representation
+// with ElementBuilder's tree -- see ComponentDef.Elements.
+type TemplateElementBuilder struct {
+	cb *ComponentDefBuilder
+	el *Element
+}
+
+// Element returns the underlying *Element.
+func (teb *TemplateElementBuilder) Element() *Element { return teb.el }
+
+// AddChild appends a new element of the given type as the last child of
+// teb's element, within the same component template, and returns a
+// builder for it.
+func (teb *TemplateElementBuilder) AddChild(elementType uint8) *TemplateElementBuilder {
+	def := &teb.cb.b.doc.ComponentDefs[teb.cb.index]
+	child := &Element{Index: len(def.Elements), Type: elementType}
+	def.Elements = append(def.Elements, child)
+	teb.el.Children = append(teb.el.Children, child.Index)
+	return &TemplateElementBuilder{cb: teb.cb, el: child}
+}
+
+// SetID names teb's element.
+func (teb *TemplateElementBuilder) SetID(name string) *TemplateElementBuilder {
+	teb.el.ID = teb.cb.b.String(name)
+	return teb
+}
+
+// SetBounds sets teb's element's declared position and size.
+func (teb *TemplateElementBuilder) SetBounds(x, y, width, height uint16) *TemplateElementBuilder {
+	teb.el.PosX, teb.el.PosY, teb.el.Width, teb.el.Height = x, y, width, height
+	return teb
+}
+
+// SetLayout sets teb's element's layout byte.
+func (teb *TemplateElementBuilder) SetLayout(layout uint8) *TemplateElementBuilder {
+	teb.el.Layout = layout
+	return teb
+}
+
+// SetStyle attaches a style by ID.
+func (teb *TemplateElementBuilder) SetStyle(styleID uint8) *TemplateElementBuilder {
+	teb.el.StyleID = styleID
+	return teb
+}
+
+// AddProperty appends a standard property to teb's element.
+func (teb *TemplateElementBuilder) AddProperty(id, valueType uint8, value []byte) *TemplateElementBuilder {
+	teb.el.Properties = append(teb.el.Properties, Property{ID: id, Type: valueType, Size: uint8(len(value)), Value: value})
+	return teb
+}
+
+// AddCustomProperty appends a custom property to teb's element, interning
+// key into the string table.
+func (teb *TemplateElementBuilder) AddCustomProperty(key string, valueType uint8, value []byte) *TemplateElementBuilder {
+	teb.el.CustomProperties = append(teb.el.CustomProperties,
+		CustomProperty{KeyIndex: teb.cb.b.String(key), Type: valueType, Size: uint8(len(value)), Value: value})
+	return teb
+}
@@ -0,0 +1,139 @@
+package krb
+
+import "testing"
+
+func TestDocumentBuilderStringInternsRepeatedValues(t *testing.T) {
+	b := NewDocumentBuilder()
+	first := b.String("hello")
+	second := b.String("hello")
+	if first != second {
+		t.Fatalf("String(\"hello\") returned different indices: %d, %d", first, second)
+	}
+	if b.String("") != 0 {
+		t.Fatalf("String(\"\") = %d, want 0 (seeded up front)", b.String(""))
+	}
+}
+
+func TestDocumentBuilderBuildsTreeWithChildrenAndBounds(t *testing.T) {
+	b := NewDocumentBuilder()
+	root := b.AddElement(ElemTypeApp)
+	root.AddChild(ElemTypeText).SetID("greeting").SetBounds(10, 20, 100, 30)
+	root.AddChild(ElemTypeButton)
+	doc := b.Build()
+
+	if len(doc.Elements) != 3 {
+		t.Fatalf("len(doc.Elements) = %d, want 3", len(doc.Elements))
+	}
+	if got := doc.Elements[0].Children; len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("root.Children = %v, want [1 2]", got)
+	}
+	text := doc.Elements[1]
+	if text.PosX != 10 || text.PosY != 20 || text.Width != 100 || text.Height != 30 {
+		t.Fatalf("text bounds = (%d,%d,%d,%d), want (10,20,100,30)", text.PosX, text.PosY, text.Width, text.Height)
+	}
+	name, ok := doc.ElementIDName(text.ID)
+	if !ok || name != "greeting" {
+		t.Fatalf("ElementIDName(text.ID) = (%q, %v), want (\"greeting\", true)", name, ok)
+	}
+	if !doc.Header.HasFlag(FlagHasApp) {
+		t.Fatalf("expected Build to set FlagHasApp for a tree containing an ElemTypeApp element")
+	}
+}
+
+func TestDocumentBuilderStyleIDsStartAtOne(t *testing.T) {
+	b := NewDocumentBuilder()
+	first := b.AddStyle("bold")
+	second := b.AddStyle("italic")
+
+	if first.ID() != 1 || second.ID() != 2 {
+		t.Fatalf("style IDs = %d, %d, want 1, 2", first.ID(), second.ID())
+	}
+
+	el := b.AddElement(ElemTypeText).SetStyle(first.ID()).Element()
+	if el.StyleID != 1 {
+		t.Fatalf("el.StyleID = %d, want 1", el.StyleID)
+	}
+	doc := b.Build()
+	if !doc.Header.HasFlag(FlagHasStyles) {
+		t.Fatalf("expected Build to set FlagHasStyles")
+	}
+}
+
+func TestDocumentBuilderElementProperties(t *testing.T) {
+	b := NewDocumentBuilder()
+	el := b.AddElement(ElemTypeButton).
+		AddProperty(PropIDBackgroundColor, ValTypeColor, []byte{255, 0, 0, 255}).
+		AddCustomProperty("hitPadding", ValTypeCustom, []byte{5}).
+		AddEvent(EventTypeClick, "onSubmit").
+		Element()
+
+	if len(el.Properties) != 1 || el.Properties[0].ID != PropIDBackgroundColor {
+		t.Fatalf("el.Properties = %v, want one PropIDBackgroundColor entry", el.Properties)
+	}
+	if len(el.CustomProperties) != 1 {
+		t.Fatalf("el.CustomProperties = %v, want one entry", el.CustomProperties)
+	}
+	if len(el.Events) != 1 || el.Events[0].Type != EventTypeClick {
+		t.Fatalf("el.Events = %v, want one EventTypeClick entry", el.Events)
+	}
+}
+
+func TestDocumentBuilderComponentDefAndInstance(t *testing.T) {
+	b := NewDocumentBuilder()
+	def := b.AddComponentDef("Widget")
+	def.AddPropertyDef("label", ValTypeString, []byte{0})
+	def.Root(ElemTypeContainer).AddChild(ElemTypeText)
+
+	root := b.AddElement(ElemTypeApp)
+	root.AddComponentInstance("Widget")
+	doc := b.Build()
+
+	if len(doc.ComponentDefs) != 1 {
+		t.Fatalf("len(doc.ComponentDefs) = %d, want 1", len(doc.ComponentDefs))
+	}
+	componentDef := doc.ComponentDefs[0]
+	if len(componentDef.PropertyDefs) != 1 || doc.String(componentDef.PropertyDefs[0].NameIndex) != "label" {
+		t.Fatalf("componentDef.PropertyDefs = %v, want one \"label\" entry", componentDef.PropertyDefs)
+	}
+	if len(componentDef.Elements) != 2 || componentDef.Root != componentDef.Elements[0] {
+		t.Fatalf("componentDef template tree malformed: %+v", componentDef)
+	}
+
+	placeholder := doc.Elements[1]
+	var found bool
+	for _, cp := range placeholder.CustomProperties {
+		if doc.String(cp.KeyIndex) == "_componentName" && doc.String(cp.Value[0]) == "Widget" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected placeholder to carry a _componentName custom property naming \"Widget\", got %+v", placeholder.CustomProperties)
+	}
+	if !doc.Header.HasFlag(FlagHasComponentDefs) {
+		t.Fatalf("expected Build to set FlagHasComponentDefs")
+	}
+}
+
+func TestDocumentBuilderResources(t *testing.T) {
+	b := NewDocumentBuilder()
+	inline := b.AddResource(ResTypeImage, 0, []byte{1, 2, 3})
+	external := b.AddExternalResource(ResTypeFont, "fonts/regular.ttf")
+	doc := b.Build()
+
+	if inline != 0 || external != 1 {
+		t.Fatalf("resource indices = %d, %d, want 0, 1", inline, external)
+	}
+	if got := doc.Resources[0].Data; len(got) != 3 {
+		t.Fatalf("doc.Resources[0].Data = %v, want 3 bytes", got)
+	}
+	path, ok := "", false
+	if idx := doc.Resources[1].DataIndex; int(idx) < len(doc.Strings) {
+		path, ok = doc.Strings[idx], true
+	}
+	if !ok || path != "fonts/regular.ttf" {
+		t.Fatalf("doc.Resources[1] path = %q, want \"fonts/regular.ttf\"", path)
+	}
+	if !doc.Header.HasFlag(FlagHasResources) {
+		t.Fatalf("expected Build to set FlagHasResources")
+	}
+}
@@ -0,0 +1,63 @@
+package krb
+
+import "testing"
+
+// oneElementBlock appends a minimal element header (with the given child
+// count and no properties/custom properties/state sets/events/anims) plus
+// childCount placeholder 2-byte child-offset entries.
+func oneElementBlock(elemType byte, childCount int) []byte {
+	b := make([]byte, ElementHeaderSize)
+	b[0] = elemType
+	b[13] = byte(childCount)
+	for i := 0; i < childCount; i++ {
+		b = append(b, 0, 0)
+	}
+	return b
+}
+
+func TestReadComponentTemplateLinksNestedChildren(t *testing.T) {
+	// Root (1 child) immediately followed by its one child (0 children),
+	// exactly as readComponentTemplate expects a template to be laid out.
+	data := append(oneElementBlock(ElemTypeContainer, 1), oneElementBlock(ElemTypeText, 0)...)
+
+	elements, next, err := readComponentTemplate(data, 0)
+	if err != nil {
+		t.Fatalf("readComponentTemplate: %v", err)
+	}
+	if next != len(data) {
+		t.Fatalf("next = %d, want %d (consumed the whole buffer)", next, len(data))
+	}
+	if len(elements) != 2 {
+		t.Fatalf("len(elements) = %d, want 2", len(elements))
+	}
+	root := elements[0]
+	if root.Type != ElemTypeContainer {
+		t.Fatalf("root.Type = %#x, want Container", root.Type)
+	}
+	if len(root.Children) != 1 || root.Children[0] != 1 {
+		t.Fatalf("root.Children = %v, want [1]", root.Children)
+	}
+	if elements[1].Type != ElemTypeText {
+		t.Fatalf("elements[1].Type = %#x, want Text", elements[1].Type)
+	}
+}
+
+func TestReadComponentTemplateLinksGrandchildren(t *testing.T) {
+	// root(1 child) -> mid(1 child) -> leaf(0 children), all contiguous.
+	data := append(oneElementBlock(ElemTypeContainer, 1), oneElementBlock(ElemTypeContainer, 1)...)
+	data = append(data, oneElementBlock(ElemTypeText, 0)...)
+
+	elements, _, err := readComponentTemplate(data, 0)
+	if err != nil {
+		t.Fatalf("readComponentTemplate: %v", err)
+	}
+	if len(elements) != 3 {
+		t.Fatalf("len(elements) = %d, want 3", len(elements))
+	}
+	if len(elements[0].Children) != 1 || elements[0].Children[0] != 1 {
+		t.Fatalf("root.Children = %v, want [1]", elements[0].Children)
+	}
+	if len(elements[1].Children) != 1 || elements[1].Children[0] != 2 {
+		t.Fatalf("mid.Children = %v, want [2]", elements[1].Children)
+	}
+}
@@ -0,0 +1,106 @@
+package krb
+
+import "fmt"
+
+// PropertyIDString returns the PROP_ID_* name for id, or a hex fallback
+// for unknown/custom IDs. Intended for logging and debug dumps, not
+// parsing -- names may gain detail across versions.
+func PropertyIDString(id uint8) string {
+	if name, ok := propertyIDNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("PropID(0x%02X)", id)
+}
+
+// ValueTypeString returns the VAL_TYPE_* name for t, or a hex fallback.
+func ValueTypeString(t uint8) string {
+	if name, ok := valueTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ValType(0x%02X)", t)
+}
+
+// ElementTypeString returns the ELEM_TYPE_* name for t, or a hex
+// fallback for unknown/custom types. Intended for logging and debug
+// dumps, not parsing.
+func ElementTypeString(t uint8) string {
+	if name, ok := elementTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ElemType(0x%02X)", t)
+}
+
+var elementTypeNames = map[uint8]string{
+	ElemTypeApp:        "App",
+	ElemTypeContainer:  "Container",
+	ElemTypeText:       "Text",
+	ElemTypeImage:      "Image",
+	ElemTypeCanvas:     "Canvas",
+	ElemTypeButton:     "Button",
+	ElemTypeInput:      "Input",
+	ElemTypeList:       "List",
+	ElemTypeGrid:       "Grid",
+	ElemTypeScrollable: "Scrollable",
+	ElemTypeVideo:      "Video",
+}
+
+var propertyIDNames = map[uint8]string{
+	PropIDBackgroundColor: "BackgroundColor",
+	PropIDForegroundColor: "ForegroundColor",
+	PropIDBorderColor:     "BorderColor",
+	PropIDBorderWidth:     "BorderWidth",
+	PropIDBorderRadius:    "BorderRadius",
+	PropIDPadding:         "Padding",
+	PropIDMargin:          "Margin",
+	PropIDTextContent:     "TextContent",
+	PropIDFontSize:        "FontSize",
+	PropIDFontWeight:      "FontWeight",
+	PropIDTextAlignment:   "TextAlignment",
+	PropIDImageSource:     "ImageSource",
+	PropIDOpacity:         "Opacity",
+	PropIDZIndex:          "ZIndex",
+	PropIDVisibility:      "Visibility",
+	PropIDGap:             "Gap",
+	PropIDMinWidth:        "MinWidth",
+	PropIDMinHeight:       "MinHeight",
+	PropIDMaxWidth:        "MaxWidth",
+	PropIDMaxHeight:       "MaxHeight",
+	PropIDAspectRatio:     "AspectRatio",
+	PropIDTransform:       "Transform",
+	PropIDShadow:          "Shadow",
+	PropIDOverflow:        "Overflow",
+	PropIDCustomDataBlob:  "CustomDataBlob",
+	PropIDLayoutFlags:     "LayoutFlags",
+	PropIDWindowWidth:     "WindowWidth",
+	PropIDWindowHeight:    "WindowHeight",
+	PropIDWindowTitle:     "WindowTitle",
+	PropIDResizable:       "Resizable",
+	PropIDKeepAspect:      "KeepAspect",
+	PropIDScaleFactor:     "ScaleFactor",
+	PropIDIcon:            "Icon",
+	PropIDVersion:         "Version",
+	PropIDAuthor:          "Author",
+	PropIDCursor:          "Cursor",
+	PropIDTooltip:         "Tooltip",
+
+	PropIDBorderColorTop:    "BorderColorTop",
+	PropIDBorderColorRight:  "BorderColorRight",
+	PropIDBorderColorBottom: "BorderColorBottom",
+	PropIDBorderColorLeft:   "BorderColorLeft",
+	PropIDBorderStyle:       "BorderStyle",
+}
+
+var valueTypeNames = map[uint8]string{
+	ValTypeNone:       "None",
+	ValTypeByte:       "Byte",
+	ValTypeShort:      "Short",
+	ValTypeColor:      "Color",
+	ValTypeString:     "String",
+	ValTypeResource:   "Resource",
+	ValTypePercentage: "Percentage",
+	ValTypeRectangle:  "Rectangle",
+	ValTypeEdgeInsets: "EdgeInsets",
+	ValTypeEnum:       "Enum",
+	ValTypeVector:     "Vector",
+	ValTypeCustom:     "Custom",
+}
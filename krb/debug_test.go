@@ -0,0 +1,21 @@
+package krb
+
+import "testing"
+
+func TestPropertyIDStringKnownAndUnknown(t *testing.T) {
+	if got := PropertyIDString(PropIDBackgroundColor); got != "BackgroundColor" {
+		t.Fatalf("got %q", got)
+	}
+	if got := ValueTypeString(0xEE); got != "ValType(0xEE)" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestElementTypeStringKnownAndUnknown(t *testing.T) {
+	if got := ElementTypeString(ElemTypeButton); got != "Button" {
+		t.Fatalf("got %q", got)
+	}
+	if got := ElementTypeString(0xEE); got != "ElemType(0xEE)" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,324 @@
+package krb
+
+// Header is the decoded 54-byte KRB file header.
+type Header struct {
+	Version           uint16
+	Flags             uint16
+	ElementCount      uint16
+	StyleCount        uint16
+	ComponentDefCount uint16
+	AnimationCount    uint16
+	ScriptCount       uint16
+	StringCount       uint16
+	ResourceCount     uint16
+
+	ElementOffset      uint32
+	StyleOffset        uint32
+	ComponentDefOffset uint32
+	AnimationOffset    uint32
+	ScriptOffset       uint32
+	StringOffset       uint32
+	ResourceOffset     uint32
+	TotalSize          uint32
+}
+
+// HasFlag reports whether the given header flag bit is set.
+func (h Header) HasFlag(flag uint16) bool {
+	return h.Flags&flag != 0
+}
+
+// Property is a single standard (ID, Type, Value) triple attached to an
+// element or a style.
+type Property struct {
+	ID    uint8
+	Type  uint8
+	Size  uint8
+	Value []byte
+}
+
+// CustomProperty is a key/value pair whose key is a string-table index,
+// used for runtime-interpreted data such as component placeholder hints.
+type CustomProperty struct {
+	KeyIndex uint8
+	Type     uint8
+	Size     uint8
+	Value    []byte
+}
+
+// StatePropertySet holds a set of property overrides that apply while one
+// or more interaction states (hover, active, focus, ...) are active.
+type StatePropertySet struct {
+	StateFlags uint8
+	Properties []Property
+}
+
+// Event binds an EVENT_TYPE_* to the string-table index of a callback name.
+type Event struct {
+	Type       uint8
+	CallbackID uint8
+}
+
+// AnimationRef binds an element to an animation table entry and the
+// trigger that starts it.
+type AnimationRef struct {
+	AnimationIndex uint8
+	Trigger        uint8
+}
+
+// Element is a single decoded Element Block, indexed within Document.Elements.
+// ChildOffsets are the raw byte offsets read from the file; Children holds
+// the resolved indices into Document.Elements once the tree is linked.
+type Element struct {
+	Index int
+
+	Type    uint8
+	ID      uint8
+	PosX    uint16
+	PosY    uint16
+	Width   uint16
+	Height  uint16
+	Layout  uint8
+	StyleID uint8
+
+	Properties       []Property
+	CustomProperties []CustomProperty
+	StateProperties  []StatePropertySet
+	Events           []Event
+	AnimationRefs    []AnimationRef
+
+	ChildOffsets []uint16
+	Children     []int
+	SelfOffset   uint32
+}
+
+// Style is a named, reusable set of standard properties.
+type Style struct {
+	ID         uint8
+	NameIndex  uint8
+	Properties []Property
+}
+
+// PropertyDef describes one property a component definition accepts.
+type PropertyDef struct {
+	NameIndex     uint8
+	ValueTypeHint uint8
+	DefaultValue  []byte
+}
+
+// ComponentDef is a reusable component template parsed from the
+// Component Definition Table.
+type ComponentDef struct {
+	NameIndex    uint8
+	PropertyDefs []PropertyDef
+
+	// Elements is the template's element tree flattened depth-first,
+	// with Elements[0] (also available as Root) as the template's root.
+	// Each element's Children holds indices into this slice, scoped to
+	// the template -- never into the main Document's Elements, even
+	// though a template root with template children shares the exact
+	// same Element representation as the main tree.
+	Elements []*Element
+	Root     *Element
+}
+
+// Resource describes an external or inline resource (image, font, ...).
+type Resource struct {
+	Type      uint8
+	NameIndex uint8
+	Format    uint8
+	DataIndex uint8 // string index when Format == external
+	Data      []byte
+}
+
+// Animation is left opaque for now; runtimes that need to drive
+// animations decode the Properties/Keyframes on demand.
+type Animation struct {
+	Type uint8
+	ID   uint8
+	Raw  []byte
+}
+
+// Document is the fully decoded contents of a .krb file.
+type Document struct {
+	Header Header
+
+	Elements      []*Element
+	Styles        []Style
+	ComponentDefs []ComponentDef
+	Animations    []Animation
+	Strings       []string
+	Resources     []Resource
+
+	// Raw holds the original file bytes when DecodeBytes/Decode was
+	// called with KeepRawData, nil otherwise. It lets a caller re-slice
+	// inline resource data or string bytes directly instead of going
+	// through String/Resource, without needing to keep its own copy of
+	// (or re-read) the source file.
+	Raw []byte
+}
+
+// String returns the string at index i, or "" if the index is out of
+// range. Index 0 is commonly used by callers as a "no string" sentinel,
+// but String itself doesn't special-case it -- it just resolves whatever
+// is actually stored at Strings[0], which is conventionally empty but
+// not guaranteed to be. Code treating 0 as a hard "absent" sentinel
+// (e.g. an Element's ID, where the format spec defines 0 as always
+// meaning "no ID") should check for it explicitly rather than relying on
+// Strings[0] happening to be empty -- see ElementIDName.
+func (d *Document) String(index uint8) string {
+	if int(index) >= len(d.Strings) {
+		return ""
+	}
+	return d.Strings[index]
+}
+
+// ElementIDName resolves an Element.ID field to the runtime ID string it
+// names, and reports whether the element has an ID at all. Per the KRB
+// format spec, an ID of 0 always means "no ID", independent of whatever
+// the string table holds at index 0 -- so this checks for 0 explicitly
+// rather than delegating straight to String, which would otherwise
+// conflate "no ID" with "named by the string at index 0" if a string
+// table ever has real content there.
+func (d *Document) ElementIDName(id uint8) (string, bool) {
+	if id == 0 {
+		return "", false
+	}
+	return d.String(id), true
+}
+
+// Element returns the element at index i, or nil if out of range.
+func (d *Document) Element(index int) *Element {
+	if index < 0 || index >= len(d.Elements) {
+		return nil
+	}
+	return d.Elements[index]
+}
+
+// ElementDataBlob returns the first PropIDCustomDataBlob payload
+// attached directly to the element at elementIndex (not one inherited
+// from a style), and reports whether it has one. The returned slice is a
+// defensive copy, safe for a caller to retain or modify without
+// affecting d.
+func (d *Document) ElementDataBlob(elementIndex int) ([]byte, bool) {
+	blobs := d.ElementDataBlobs(elementIndex)
+	if len(blobs) == 0 {
+		return nil, false
+	}
+	return blobs[0], true
+}
+
+// ElementDataBlobs returns every PropIDCustomDataBlob payload attached
+// directly to the element at elementIndex, in declaration order, each as
+// a defensive copy. A compiler that emits more than one such property on
+// the same element (e.g. several app-specific data chunks) has all of
+// them surfaced here rather than only the last. It returns nil for an
+// out-of-range index or an element with no such property.
+func (d *Document) ElementDataBlobs(elementIndex int) [][]byte {
+	el := d.Element(elementIndex)
+	if el == nil {
+		return nil
+	}
+	var blobs [][]byte
+	for _, p := range el.Properties {
+		if p.ID == PropIDCustomDataBlob {
+			blobs = append(blobs, append([]byte(nil), p.Value...))
+		}
+	}
+	return blobs
+}
+
+// Resource returns the resource at index i and true, or the zero Resource
+// and false if out of range.
+func (d *Document) Resource(index uint8) (Resource, bool) {
+	if int(index) >= len(d.Resources) {
+		return Resource{}, false
+	}
+	return d.Resources[index], true
+}
+
+// Metadata is document-level information an application wants without
+// spelunking raw properties -- an "About" dialog, a crash report, or
+// analytics wanting the compiled app version and the format it was built
+// against.
+type Metadata struct {
+	// FormatVersionMajor/Minor is the KRB format version this file was
+	// compiled for, decoded from Header.Version (see decodeBytes' comment
+	// on its Minor<<8|Major packing).
+	FormatVersionMajor uint8
+	FormatVersionMinor uint8
+
+	// AppVersion and Author come from the App element's PropIDVersion and
+	// PropIDAuthor properties, or "" if the compiler never set them.
+	AppVersion string
+	Author     string
+
+	// WindowTitle is the App element's PropIDWindowTitle property, or ""
+	// if unset. This is the raw compiled string; SetTranslations
+	// substitution happens later, in the render package.
+	WindowTitle string
+
+	// HasStyles through HasStateProperties mirror the header flag of the
+	// same name (see Header.HasFlag), decoded into booleans so a caller
+	// doesn't need to know the individual Flag* bit constants.
+	HasStyles          bool
+	HasComponentDefs   bool
+	HasAnimations      bool
+	HasResources       bool
+	Compressed         bool
+	FixedPoint         bool
+	ExtendedColor      bool
+	HasApp             bool
+	HasScripts         bool
+	HasStateProperties bool
+}
+
+// Metadata resolves d's document-level information: the KRB format
+// version, the header flags, and the App element's declared version,
+// author, and window title. It's safe to call on a document with no App
+// element at all -- AppVersion, Author, and WindowTitle are simply ""
+// rather than a panic.
+//
+// Like ElementDataBlobs, this scans on every call rather than caching --
+// a document rarely has more than one App element, so the scan is cheap,
+// and it means a *Document assembled by hand or by DocumentBuilder
+// behaves identically to one DecodeBytes returned, with no separate
+// "resolve metadata now" step to remember.
+func (d *Document) Metadata() Metadata {
+	m := Metadata{
+		FormatVersionMajor: uint8(d.Header.Version),
+		FormatVersionMinor: uint8(d.Header.Version >> 8),
+		HasStyles:          d.Header.HasFlag(FlagHasStyles),
+		HasComponentDefs:   d.Header.HasFlag(FlagHasComponentDefs),
+		HasAnimations:      d.Header.HasFlag(FlagHasAnimations),
+		HasResources:       d.Header.HasFlag(FlagHasResources),
+		Compressed:         d.Header.HasFlag(FlagCompressed),
+		FixedPoint:         d.Header.HasFlag(FlagFixedPoint),
+		ExtendedColor:      d.Header.HasFlag(FlagExtendedColor),
+		HasApp:             d.Header.HasFlag(FlagHasApp),
+		HasScripts:         d.Header.HasFlag(FlagHasScripts),
+		HasStateProperties: d.Header.HasFlag(FlagHasStateProperties),
+	}
+	for _, el := range d.Elements {
+		if el.Type != ElemTypeApp {
+			continue
+		}
+		for _, p := range el.Properties {
+			switch p.ID {
+			case PropIDVersion:
+				if len(p.Value) > 0 {
+					m.AppVersion = d.String(p.Value[0])
+				}
+			case PropIDAuthor:
+				if len(p.Value) > 0 {
+					m.Author = d.String(p.Value[0])
+				}
+			case PropIDWindowTitle:
+				if len(p.Value) > 0 {
+					m.WindowTitle = d.String(p.Value[0])
+				}
+			}
+		}
+		break
+	}
+	return m
+}
@@ -0,0 +1,129 @@
+package krb
+
+import "testing"
+
+func TestElementIDNameTreatsZeroAsNoIDRegardlessOfStringTable(t *testing.T) {
+	doc := &Document{Strings: []string{"oops", "main"}}
+
+	name, ok := doc.ElementIDName(0)
+	if ok || name != "" {
+		t.Fatalf("ElementIDName(0) = (%q, %v), want (\"\", false) even though Strings[0] is non-empty", name, ok)
+	}
+
+	name, ok = doc.ElementIDName(1)
+	if !ok || name != "main" {
+		t.Fatalf("ElementIDName(1) = (%q, %v), want (\"main\", true)", name, ok)
+	}
+}
+
+func TestElementIDNameOutOfRangeIndex(t *testing.T) {
+	doc := &Document{Strings: []string{""}}
+
+	name, ok := doc.ElementIDName(5)
+	if !ok || name != "" {
+		t.Fatalf("ElementIDName(5) = (%q, %v), want (\"\", true) for a non-zero but out-of-range index", name, ok)
+	}
+}
+
+func TestElementDataBlobReturnsFirstBlobAsACopy(t *testing.T) {
+	payload := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+	el := &Element{
+		Index:      0,
+		Properties: []Property{{ID: PropIDCustomDataBlob, Type: ValTypeCustom, Value: payload}},
+	}
+	doc := &Document{Elements: []*Element{el}}
+
+	got, ok := doc.ElementDataBlob(0)
+	if !ok {
+		t.Fatalf("ElementDataBlob(0) ok = false, want true")
+	}
+	got[0] = 0xFF
+	if el.Properties[0].Value[0] != 0xDE {
+		t.Fatalf("mutating the returned blob corrupted the element's property bytes")
+	}
+}
+
+func TestElementDataBlobNoneFound(t *testing.T) {
+	el := &Element{Index: 0}
+	doc := &Document{Elements: []*Element{el}}
+
+	if _, ok := doc.ElementDataBlob(0); ok {
+		t.Fatalf("expected ok = false for an element with no PropIDCustomDataBlob property")
+	}
+	if _, ok := doc.ElementDataBlob(5); ok {
+		t.Fatalf("expected ok = false for an out-of-range element index")
+	}
+}
+
+func TestElementDataBlobsReturnsEveryBlobInOrder(t *testing.T) {
+	first, second := []byte{1, 2}, []byte{3, 4, 5}
+	el := &Element{
+		Index: 0,
+		Properties: []Property{
+			{ID: PropIDCustomDataBlob, Type: ValTypeCustom, Value: first},
+			{ID: PropIDBackgroundColor, Type: ValTypeColor, Value: []byte{0}},
+			{ID: PropIDCustomDataBlob, Type: ValTypeCustom, Value: second},
+		},
+	}
+	doc := &Document{Elements: []*Element{el}}
+
+	blobs := doc.ElementDataBlobs(0)
+	if len(blobs) != 2 || string(blobs[0]) != string(first) || string(blobs[1]) != string(second) {
+		t.Fatalf("ElementDataBlobs(0) = %v, want [%v %v]", blobs, first, second)
+	}
+}
+
+func TestStringReturnsWhateverIsAtIndexZero(t *testing.T) {
+	doc := &Document{Strings: []string{"not empty"}}
+
+	if got := doc.String(0); got != "not empty" {
+		t.Fatalf("String(0) = %q, want %q -- String does not special-case index 0", got, "not empty")
+	}
+}
+
+func TestMetadataResolvesVersionAuthorAndTitleFromTheAppElement(t *testing.T) {
+	b := NewDocumentBuilder()
+	b.AddElement(ElemTypeApp).
+		AddProperty(PropIDVersion, ValTypeString, []byte{b.String("1.2.3")}).
+		AddProperty(PropIDAuthor, ValTypeString, []byte{b.String("Ada")}).
+		AddProperty(PropIDWindowTitle, ValTypeString, []byte{b.String("My App")})
+	doc := b.Build()
+	doc.Header.Version = 5<<8 | 3 // major 3, minor 5
+
+	m := doc.Metadata()
+	if m.AppVersion != "1.2.3" {
+		t.Fatalf("AppVersion = %q, want %q", m.AppVersion, "1.2.3")
+	}
+	if m.Author != "Ada" {
+		t.Fatalf("Author = %q, want %q", m.Author, "Ada")
+	}
+	if m.WindowTitle != "My App" {
+		t.Fatalf("WindowTitle = %q, want %q", m.WindowTitle, "My App")
+	}
+	if m.FormatVersionMajor != 3 || m.FormatVersionMinor != 5 {
+		t.Fatalf("FormatVersion = %d.%d, want 3.5", m.FormatVersionMajor, m.FormatVersionMinor)
+	}
+	if !m.HasApp {
+		t.Fatalf("HasApp = false, want true for a document with an App element")
+	}
+}
+
+func TestMetadataLeavesStringFieldsEmptyWhenAppSetsNeither(t *testing.T) {
+	b := NewDocumentBuilder()
+	b.AddElement(ElemTypeApp)
+	doc := b.Build()
+
+	m := doc.Metadata()
+	if m.AppVersion != "" || m.Author != "" || m.WindowTitle != "" {
+		t.Fatalf("Metadata() = %+v, want empty AppVersion/Author/WindowTitle rather than a panic", m)
+	}
+}
+
+func TestMetadataOnADocumentWithNoAppElement(t *testing.T) {
+	doc := &Document{Strings: []string{""}}
+
+	m := doc.Metadata()
+	if m.HasApp || m.AppVersion != "" {
+		t.Fatalf("Metadata() = %+v, want zero-value App fields when there's no App element at all", m)
+	}
+}
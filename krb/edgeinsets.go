@@ -0,0 +1,38 @@
+package krb
+
+// DecodeEdgeInsets decodes a VAL_TYPE_EDGEINSETS value into its four
+// sides (top, right, bottom, left), in that spec order.
+//
+// Four encodings are accepted, matching CSS shorthand: a 1-byte form
+// (one uint8 applied to all four sides), a 2-byte form (uint8 vertical,
+// uint8 horizontal), a 4-byte form (one uint8 per side, capped at 255
+// unscaled units), and an 8-byte form (four little-endian uint16s) so
+// padding and border widths on highly-scaled UIs aren't clipped. The
+// value's length disambiguates which one was written; any other length
+// decodes to all-zero insets.
+func DecodeEdgeInsets(value []byte) [4]float32 {
+	switch len(value) {
+	case 1:
+		v := float32(value[0])
+		return [4]float32{v, v, v, v}
+	case 2:
+		vertical, horizontal := float32(value[0]), float32(value[1])
+		return [4]float32{vertical, horizontal, vertical, horizontal}
+	case 4:
+		return [4]float32{
+			float32(value[0]),
+			float32(value[1]),
+			float32(value[2]),
+			float32(value[3]),
+		}
+	case 8:
+		return [4]float32{
+			float32(le16(value[0:2])),
+			float32(le16(value[2:4])),
+			float32(le16(value[4:6])),
+			float32(le16(value[6:8])),
+		}
+	default:
+		return [4]float32{}
+	}
+}
@@ -0,0 +1,37 @@
+package krb
+
+import "testing"
+
+func TestDecodeEdgeInsetsByteForm(t *testing.T) {
+	got := DecodeEdgeInsets([]byte{1, 2, 3, 4})
+	want := [4]float32{1, 2, 3, 4}
+	if got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestDecodeEdgeInsetsAllSidesForm(t *testing.T) {
+	got := DecodeEdgeInsets([]byte{5})
+	want := [4]float32{5, 5, 5, 5}
+	if got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestDecodeEdgeInsetsVerticalHorizontalForm(t *testing.T) {
+	got := DecodeEdgeInsets([]byte{1, 2})
+	want := [4]float32{1, 2, 1, 2}
+	if got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestDecodeEdgeInsetsShortFormAboveByteRange(t *testing.T) {
+	// 300 doesn't fit in a uint8, which is exactly what the 8-byte
+	// encoding exists to avoid.
+	got := DecodeEdgeInsets([]byte{44, 1, 0, 0, 0, 0, 0, 0}) // 300, 0, 0, 0 little-endian
+	want := [4]float32{300, 0, 0, 0}
+	if got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,245 @@
+// Package krb decodes the Kryon binary UI format (.krb) described in
+// docs/krb_source_spec.md. It only concerns itself with parsing the file
+// into in-memory structures; layout, styling and rendering are the
+// responsibility of package render and its backends.
+package krb
+
+// Magic is the 4-byte file identifier ("KRB1").
+var Magic = [4]byte{'K', 'R', 'B', '1'}
+
+// HeaderSize is the size in bytes of the v0.5 (current) file header.
+const HeaderSize = 54
+
+// HeaderSizeV4 is the size in bytes of the v0.4 file header, which added
+// Component Def Count/Offset over v0.2/v0.3 but predates Script
+// Count/Offset.
+const HeaderSizeV4 = 48
+
+// HeaderSizeV2 is the size in bytes of the file header used through
+// v0.1-v0.3, before component definitions or embedded scripts existed.
+// v0.3's own header change only affected the Element Header, not this.
+const HeaderSizeV2 = 42
+
+// ElementHeaderSize is the size in bytes of a v0.5 element header.
+const ElementHeaderSize = 18
+
+// Header flags (bit positions within the 16-bit Flags field).
+const (
+	FlagHasStyles uint16 = 1 << iota
+	FlagHasComponentDefs
+	FlagHasAnimations
+	FlagHasResources
+	FlagCompressed
+	FlagFixedPoint
+	FlagExtendedColor
+	FlagHasApp
+	FlagHasScripts
+	FlagHasStateProperties
+)
+
+// Element types (ELEM_TYPE_*).
+const (
+	ElemTypeApp        uint8 = 0x00
+	ElemTypeContainer  uint8 = 0x01
+	ElemTypeText       uint8 = 0x02
+	ElemTypeImage      uint8 = 0x03
+	ElemTypeCanvas     uint8 = 0x04
+	ElemTypeButton     uint8 = 0x10
+	ElemTypeInput      uint8 = 0x11
+	ElemTypeList       uint8 = 0x20
+	ElemTypeGrid       uint8 = 0x21
+	ElemTypeScrollable uint8 = 0x22
+	ElemTypeVideo      uint8 = 0x30
+	ElemTypeCustomBase uint8 = 0x31
+)
+
+// Resource types (RESOURCE_TYPE_*), identifying what Resource.Type holds.
+const (
+	ResTypeImage  uint8 = 0x01
+	ResTypeFont   uint8 = 0x02
+	ResTypeSound  uint8 = 0x03
+	ResTypeVideo  uint8 = 0x04
+	ResTypeScript uint8 = 0x05
+	ResTypeCustom uint8 = 0x06
+)
+
+// Standard property IDs (PROP_ID_*).
+const (
+	PropIDBackgroundColor uint8 = 0x01
+	PropIDForegroundColor uint8 = 0x02
+	PropIDBorderColor     uint8 = 0x03
+	PropIDBorderWidth     uint8 = 0x04
+	PropIDBorderRadius    uint8 = 0x05
+	PropIDPadding         uint8 = 0x06
+	PropIDMargin          uint8 = 0x07
+	PropIDTextContent     uint8 = 0x08
+	PropIDFontSize        uint8 = 0x09
+	PropIDFontWeight      uint8 = 0x0A
+	PropIDTextAlignment   uint8 = 0x0B
+	PropIDImageSource     uint8 = 0x0C
+	PropIDOpacity         uint8 = 0x0D
+	PropIDZIndex          uint8 = 0x0E
+	PropIDVisibility      uint8 = 0x0F
+	PropIDGap             uint8 = 0x10
+	PropIDMinWidth        uint8 = 0x11
+	PropIDMinHeight       uint8 = 0x12
+	PropIDMaxWidth        uint8 = 0x13
+	PropIDMaxHeight       uint8 = 0x14
+	PropIDAspectRatio     uint8 = 0x15
+	PropIDTransform       uint8 = 0x16
+	PropIDShadow          uint8 = 0x17
+	PropIDOverflow        uint8 = 0x18
+	PropIDCustomDataBlob  uint8 = 0x19
+	PropIDLayoutFlags     uint8 = 0x1A
+
+	// PropIDTextDecoration is a Kryon runtime extension allocated from
+	// the unused range between Layout Flags (0x1A) and the window-level
+	// property group (0x20). Value is VAL_TYPE_ENUM, a bitmask of
+	// render.TextDecorationUnderline / render.TextDecorationStrikethrough.
+	PropIDTextDecoration uint8 = 0x1B
+
+	// PropIDLetterSpacing and PropIDLineHeight are Kryon runtime
+	// extensions allocated from the same reserved range as
+	// PropIDTextDecoration. Both are VAL_TYPE_SHORT pixel amounts; zero
+	// (the default when absent) reproduces the previous behavior of
+	// default glyph spacing and single-line stacking.
+	PropIDLetterSpacing uint8 = 0x1C
+	PropIDLineHeight    uint8 = 0x1D
+
+	// PropIDObjectFit is a Kryon runtime extension allocated from the
+	// same reserved range as PropIDTextDecoration. Value is VAL_TYPE_ENUM,
+	// one of render.ObjectFitFill (the default behavior before this
+	// property existed) / Contain / Cover / None.
+	PropIDObjectFit uint8 = 0x1E
+
+	// PropIDSpriteAnimation is a Kryon runtime extension allocated from
+	// the same reserved range as PropIDTextDecoration -- the last free
+	// slot in it. Value is VAL_TYPE_CUSTOM, 5 bytes: frame count (byte),
+	// sprite sheet grid columns (byte), grid rows (byte), and playback
+	// speed in frames per second (VAL_TYPE_SHORT, little-endian). Frames
+	// are read off the image named by PropIDImageSource, row-major
+	// left-to-right then top-to-bottom.
+	PropIDSpriteAnimation uint8 = 0x1F
+
+	PropIDWindowWidth  uint8 = 0x20
+	PropIDWindowHeight uint8 = 0x21
+	PropIDWindowTitle  uint8 = 0x22
+	PropIDResizable    uint8 = 0x23
+	PropIDKeepAspect   uint8 = 0x24
+	PropIDScaleFactor  uint8 = 0x25
+	PropIDIcon         uint8 = 0x26
+	PropIDVersion      uint8 = 0x27
+	PropIDAuthor       uint8 = 0x28
+	PropIDCursor       uint8 = 0x29
+
+	// PropIDTooltip is a Kryon runtime extension: a string-table reference
+	// to text shown in a small floating box near the cursor once an
+	// element has been continuously hovered past a short delay.
+	PropIDTooltip uint8 = 0x2A
+
+	// PropIDBorderColorTop/Right/Bottom/Left are Kryon runtime
+	// extensions overriding one border side's color on top of whatever
+	// PropIDBorderColor set for all four; each decodes the same color
+	// reference encoding PropIDBorderColor does.
+	PropIDBorderColorTop    uint8 = 0x2B
+	PropIDBorderColorRight  uint8 = 0x2C
+	PropIDBorderColorBottom uint8 = 0x2D
+	PropIDBorderColorLeft   uint8 = 0x2E
+
+	// PropIDBorderStyle is a Kryon runtime extension: a VAL_TYPE_ENUM
+	// selecting solid, dashed, or dotted border strokes (see
+	// render.BorderStyle).
+	PropIDBorderStyle uint8 = 0x2F
+
+	// PropIDFlexBasis is a Kryon runtime extension allocated from the
+	// unused range following the border-style group (0x2F). It's the
+	// same percentage-or-pixels encoding as PropIDMinWidth: a grow
+	// child's preferred main-axis size, reserved before any leftover
+	// space is distributed by grow factor (see render.layoutFlowChildren).
+	PropIDFlexBasis uint8 = 0x30
+
+	// PropIDGrowFactor is a Kryon runtime extension allocated from the
+	// same reserved range as PropIDFlexBasis. Value is VAL_TYPE_BYTE, how
+	// large a share of leftover main-axis space a grow child takes
+	// relative to its grow siblings (a grow=2 child gets twice the
+	// leftover space of a grow=1 sibling). Absent or zero behaves as 1,
+	// matching the equal-share distribution before this property existed.
+	PropIDGrowFactor uint8 = 0x31
+
+	// PropIDTextShadow is a Kryon runtime extension allocated from the
+	// same reserved range as PropIDFlexBasis. Value is VAL_TYPE_CUSTOM:
+	// offset X and Y (signed bytes) followed by a color reference in the
+	// same encoding PropIDBorderColor uses (a palette index, or an RGBA
+	// quad under FlagExtendedColor). Only takes effect when
+	// render.TextDecorationShadow is set on PropIDTextDecoration.
+	PropIDTextShadow uint8 = 0x32
+
+	// PropIDTextOutlineColor is a Kryon runtime extension allocated from
+	// the same reserved range as PropIDFlexBasis: the color an outlined
+	// text draws its ring in, same color reference encoding
+	// PropIDBorderColor uses. Only takes effect when
+	// render.TextDecorationOutline is set on PropIDTextDecoration.
+	PropIDTextOutlineColor uint8 = 0x33
+)
+
+// Value types (VAL_TYPE_*).
+const (
+	ValTypeNone       uint8 = 0x00
+	ValTypeByte       uint8 = 0x01
+	ValTypeShort      uint8 = 0x02
+	ValTypeColor      uint8 = 0x03
+	ValTypeString     uint8 = 0x04
+	ValTypeResource   uint8 = 0x05
+	ValTypePercentage uint8 = 0x06
+	ValTypeRectangle  uint8 = 0x07
+	ValTypeEdgeInsets uint8 = 0x08
+	ValTypeEnum       uint8 = 0x09
+	ValTypeVector     uint8 = 0x0A
+	ValTypeCustom     uint8 = 0x0B
+)
+
+// Event types (EVENT_TYPE_*).
+const (
+	EventTypeClick     uint8 = 0x01
+	EventTypePress     uint8 = 0x02
+	EventTypeRelease   uint8 = 0x03
+	EventTypeLongPress uint8 = 0x04
+	EventTypeHover     uint8 = 0x05
+	EventTypeFocus     uint8 = 0x06
+	EventTypeBlur      uint8 = 0x07
+	EventTypeChange    uint8 = 0x08
+	EventTypeSubmit    uint8 = 0x09
+	EventTypeCustom    uint8 = 0x0A
+
+	// EventTypeRightClick and EventTypeMiddleClick are Kryon runtime
+	// extensions allocated out of the spec's "Others Reserved" range for
+	// EVENT_TYPE_*; they let a .kry author bind a handler to a specific
+	// mouse button the same way EventTypeClick binds the primary button.
+	EventTypeRightClick  uint8 = 0x0B
+	EventTypeMiddleClick uint8 = 0x0C
+)
+
+// Layout byte bit layout (Element Header offset 10).
+const (
+	LayoutDirectionMask    uint8 = 0x03
+	LayoutDirectionRow     uint8 = 0x00
+	LayoutDirectionColumn  uint8 = 0x01
+	LayoutDirectionRowRev  uint8 = 0x02
+	LayoutDirectionColRev  uint8 = 0x03
+	LayoutAlignmentMask    uint8 = 0x0C
+	LayoutAlignmentStart   uint8 = 0x00 << 2
+	LayoutAlignmentCenter  uint8 = 0x01 << 2
+	LayoutAlignmentEnd     uint8 = 0x02 << 2
+	LayoutAlignmentSpaceBW uint8 = 0x03 << 2
+
+	// LayoutCrossStretchBit is a Kryon runtime extension allocated out of
+	// the Layout byte's bit 7, which krb_source_spec.md leaves Reserved.
+	// Bits 2-3 (LayoutAlignmentMask) only ever describe main-axis
+	// alignment; this bit is the cross-axis counterpart -- when set, a
+	// parent stretches its children to fill its cross axis instead of
+	// sizing them to content and positioning them per LayoutAlignmentMask.
+	LayoutCrossStretchBit uint8 = 1 << 7
+	LayoutWrapBit         uint8 = 1 << 4
+	LayoutGrowBit         uint8 = 1 << 5
+	LayoutAbsoluteBit     uint8 = 1 << 6
+)
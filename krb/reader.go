@@ -0,0 +1,529 @@
+package krb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// DecodeOption configures Decode/DecodeBytes.
+type DecodeOption func(*decodeConfig)
+
+type decodeConfig struct {
+	keepRaw bool
+}
+
+// KeepRawData retains the original file bytes on the returned Document's
+// Raw field, so later code can re-slice resource/string data directly
+// instead of going back to disk. It's opt-in because most callers never
+// need it and it otherwise doubles memory use while Document is alive
+// (the parsed Strings/Resources already cover the common cases).
+func KeepRawData() DecodeOption {
+	return func(c *decodeConfig) { c.keepRaw = true }
+}
+
+// Decode reads a complete .krb document from r.
+func Decode(r io.Reader, opts ...DecodeOption) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("krb: read input: %w", err)
+	}
+	return DecodeBytes(data, opts...)
+}
+
+// DecodeBytes parses an already-buffered .krb file. Buffering up front
+// keeps the rest of the decoder simple: every section is addressed by
+// absolute offset from the header, and elements reference each other by
+// byte offset rather than index.
+//
+// A malformed file (e.g. a corrupted property/child count that runs the
+// cursor off the end of the buffer) must produce an error, never a panic
+// -- every section reader checks each field's length before slicing, but
+// as a last line of defense any unexpected out-of-range access is also
+// recovered here and turned into an error.
+func DecodeBytes(data []byte, opts ...DecodeOption) (doc *Document, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			doc = nil
+			err = fmt.Errorf("krb: malformed file: %v", r)
+		}
+	}()
+	cfg := decodeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return decodeBytes(data, cfg)
+}
+
+func decodeBytes(data []byte, cfg decodeConfig) (*Document, error) {
+	if len(data) < minHeaderSize {
+		return nil, fmt.Errorf("krb: file too small for header (%d bytes)", len(data))
+	}
+	if !bytes.Equal(data[0:4], Magic[:]) {
+		return nil, fmt.Errorf("krb: bad magic number %x", data[0:4])
+	}
+
+	// Version packs as Minor<<8 | Major (see krb_source_spec.md's file
+	// header field table), so the minor digit that picks the header
+	// layout below is the high byte, not the low one.
+	version := le16(data[4:6])
+	minor := uint8(version >> 8)
+	headerSize := headerSizeForMinor(minor)
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("krb: file too small for a v0.%d header (%d bytes, want %d)", minor, len(data), headerSize)
+	}
+
+	var h Header
+	switch {
+	case minor >= 5:
+		h = parseHeaderV5(data)
+	case minor == 4:
+		h = parseHeaderV4(data)
+	default:
+		h = parseHeaderV2(data)
+	}
+
+	// TotalSize (present in every header layout above) is the compiler's
+	// own record of how large the file should be; a shorter buffer means the file was cut
+	// off somewhere after the header, before decoding even reaches the
+	// section that would otherwise report the error. A buffer that's
+	// longer than TotalSize isn't treated as an error -- trailing bytes
+	// past the declared size are the file's own business (e.g. an
+	// appended signature), not something the decoder needs to reject.
+	if h.TotalSize != 0 && uint32(len(data)) < h.TotalSize {
+		return nil, fmt.Errorf("krb: file truncated: header declares %d bytes but only %d were read", h.TotalSize, len(data))
+	}
+
+	doc := &Document{Header: h}
+	if cfg.keepRaw {
+		doc.Raw = data
+	}
+
+	if h.StringOffset != 0 {
+		strs, err := readStringTable(data, h.StringOffset)
+		if err != nil {
+			return nil, fmt.Errorf("krb: strings: %w", err)
+		}
+		doc.Strings = strs
+	}
+
+	if h.ResourceOffset != 0 && h.ResourceCount != 0 {
+		res, err := readResourceTable(data, h.ResourceOffset)
+		if err != nil {
+			return nil, fmt.Errorf("krb: resources: %w", err)
+		}
+		doc.Resources = res
+	}
+
+	elements, err := readElements(data, h.ElementOffset, int(h.ElementCount))
+	if err != nil {
+		return nil, fmt.Errorf("krb: elements: %w", err)
+	}
+	doc.Elements = elements
+	linkChildren(doc.Elements)
+
+	if h.HasFlag(FlagHasStyles) && h.StyleOffset != 0 {
+		styles, err := readStyles(data, h.StyleOffset, int(h.StyleCount))
+		if err != nil {
+			return nil, fmt.Errorf("krb: styles: %w", err)
+		}
+		doc.Styles = styles
+	}
+
+	if h.HasFlag(FlagHasComponentDefs) && h.ComponentDefOffset != 0 {
+		defs, err := readComponentDefs(data, h.ComponentDefOffset, int(h.ComponentDefCount))
+		if err != nil {
+			return nil, fmt.Errorf("krb: component defs: %w", err)
+		}
+		doc.ComponentDefs = defs
+	}
+
+	return doc, nil
+}
+
+// minHeaderSize is how many bytes decodeBytes needs before it can even
+// read Version and decide which of the version-specific header layouts
+// below applies.
+const minHeaderSize = 6
+
+// headerSizeForMinor returns the on-disk file header size for a given
+// minor version, per krb_source_spec.md's change log: HeaderSizeV2 covers
+// v0.1-v0.3 (the v0.3 change only grew the Element Header, not the file
+// header), HeaderSizeV4 covers v0.4 (adds Component Def Count/Offset),
+// and HeaderSize (v0.5+) adds Script Count/Offset on top of that.
+func headerSizeForMinor(minor uint8) int {
+	switch {
+	case minor >= 5:
+		return HeaderSize
+	case minor == 4:
+		return HeaderSizeV4
+	default:
+		return HeaderSizeV2
+	}
+}
+
+// parseHeaderV5 reads the current 54-byte file header (v0.5+).
+func parseHeaderV5(data []byte) Header {
+	return Header{
+		Version:           le16(data[4:6]),
+		Flags:             le16(data[6:8]),
+		ElementCount:      le16(data[8:10]),
+		StyleCount:        le16(data[10:12]),
+		ComponentDefCount: le16(data[12:14]),
+		AnimationCount:    le16(data[14:16]),
+		ScriptCount:       le16(data[16:18]),
+		StringCount:       le16(data[18:20]),
+		ResourceCount:     le16(data[20:22]),
+
+		ElementOffset:      le32(data[22:26]),
+		StyleOffset:        le32(data[26:30]),
+		ComponentDefOffset: le32(data[30:34]),
+		AnimationOffset:    le32(data[34:38]),
+		ScriptOffset:       le32(data[38:42]),
+		StringOffset:       le32(data[42:46]),
+		ResourceOffset:     le32(data[46:50]),
+		TotalSize:          le32(data[50:54]),
+	}
+}
+
+// parseHeaderV4 reads the 48-byte file header introduced in v0.4: it adds
+// ComponentDefCount/Offset over the v0.2/v0.3 layout but predates
+// ScriptCount/Offset, which v0.5 added alongside them.
+func parseHeaderV4(data []byte) Header {
+	return Header{
+		Version:           le16(data[4:6]),
+		Flags:             le16(data[6:8]),
+		ElementCount:      le16(data[8:10]),
+		StyleCount:        le16(data[10:12]),
+		ComponentDefCount: le16(data[12:14]),
+		AnimationCount:    le16(data[14:16]),
+		StringCount:       le16(data[16:18]),
+		ResourceCount:     le16(data[18:20]),
+
+		ElementOffset:      le32(data[20:24]),
+		StyleOffset:        le32(data[24:28]),
+		ComponentDefOffset: le32(data[28:32]),
+		AnimationOffset:    le32(data[32:36]),
+		StringOffset:       le32(data[36:40]),
+		ResourceOffset:     le32(data[40:44]),
+		TotalSize:          le32(data[44:48]),
+	}
+}
+
+// parseHeaderV2 reads the 42-byte file header used through v0.3, before
+// component definitions or embedded scripts existed.
+func parseHeaderV2(data []byte) Header {
+	return Header{
+		Version:        le16(data[4:6]),
+		Flags:          le16(data[6:8]),
+		ElementCount:   le16(data[8:10]),
+		StyleCount:     le16(data[10:12]),
+		AnimationCount: le16(data[12:14]),
+		StringCount:    le16(data[14:16]),
+		ResourceCount:  le16(data[16:18]),
+
+		ElementOffset:   le32(data[18:22]),
+		StyleOffset:     le32(data[22:26]),
+		AnimationOffset: le32(data[26:30]),
+		StringOffset:    le32(data[30:34]),
+		ResourceOffset:  le32(data[34:38]),
+		TotalSize:       le32(data[38:42]),
+	}
+}
+
+func le16(b []byte) uint16 { return binary.LittleEndian.Uint16(b) }
+func le32(b []byte) uint32 { return binary.LittleEndian.Uint32(b) }
+
+func readStringTable(data []byte, offset uint32) ([]string, error) {
+	if int(offset)+2 > len(data) {
+		return nil, fmt.Errorf("offset %d out of range", offset)
+	}
+	count := int(le16(data[offset : offset+2]))
+	pos := int(offset) + 2
+	strs := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if pos+1 > len(data) {
+			return nil, fmt.Errorf("truncated string table at index %d", i)
+		}
+		n := int(data[pos])
+		pos++
+		if pos+n > len(data) {
+			return nil, fmt.Errorf("truncated string at index %d", i)
+		}
+		strs = append(strs, string(data[pos:pos+n]))
+		pos += n
+	}
+	return strs, nil
+}
+
+func readResourceTable(data []byte, offset uint32) ([]Resource, error) {
+	if int(offset)+2 > len(data) {
+		return nil, fmt.Errorf("offset %d out of range", offset)
+	}
+	count := int(le16(data[offset : offset+2]))
+	pos := int(offset) + 2
+	out := make([]Resource, 0, count)
+	for i := 0; i < count; i++ {
+		if pos+3 > len(data) {
+			return nil, fmt.Errorf("truncated resource entry %d", i)
+		}
+		r := Resource{Type: data[pos], NameIndex: data[pos+1], Format: data[pos+2]}
+		pos += 3
+		switch r.Format {
+		case 0x00: // external: 1 byte string index
+			if pos+1 > len(data) {
+				return nil, fmt.Errorf("truncated external resource %d", i)
+			}
+			r.DataIndex = data[pos]
+			pos++
+		case 0x01: // inline: 2-byte size + bytes
+			if pos+2 > len(data) {
+				return nil, fmt.Errorf("truncated inline resource %d", i)
+			}
+			size := int(le16(data[pos : pos+2]))
+			pos += 2
+			if pos+size > len(data) {
+				return nil, fmt.Errorf("truncated inline resource data %d", i)
+			}
+			r.Data = data[pos : pos+size]
+			pos += size
+		default:
+			return nil, fmt.Errorf("unknown resource format 0x%02x", r.Format)
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func readElements(data []byte, offset uint32, count int) ([]*Element, error) {
+	out := make([]*Element, 0, count)
+	pos := int(offset)
+	for i := 0; i < count; i++ {
+		el, next, err := readOneElement(data, pos, i)
+		if err != nil {
+			return nil, fmt.Errorf("element %d at offset %d: %w", i, pos, err)
+		}
+		out = append(out, el)
+		pos = next
+	}
+	return out, nil
+}
+
+func readOneElement(data []byte, pos int, index int) (*Element, int, error) {
+	if pos+ElementHeaderSize > len(data) {
+		return nil, 0, fmt.Errorf("truncated element header")
+	}
+	start := pos
+	el := &Element{
+		Index:      index,
+		Type:       data[pos],
+		ID:         data[pos+1],
+		PosX:       le16(data[pos+2 : pos+4]),
+		PosY:       le16(data[pos+4 : pos+6]),
+		Width:      le16(data[pos+6 : pos+8]),
+		Height:     le16(data[pos+8 : pos+10]),
+		Layout:     data[pos+10],
+		StyleID:    data[pos+11],
+		SelfOffset: uint32(start),
+	}
+	propCount := int(data[pos+12])
+	childCount := int(data[pos+13])
+	eventCount := int(data[pos+14])
+	animCount := int(data[pos+15])
+	customPropCount := int(data[pos+16])
+	statePropCount := int(data[pos+17])
+	pos += ElementHeaderSize
+
+	for i := 0; i < propCount; i++ {
+		p, next, err := readProperty(data, pos)
+		if err != nil {
+			return nil, 0, fmt.Errorf("property %d: %w", i, err)
+		}
+		el.Properties = append(el.Properties, p)
+		pos = next
+	}
+
+	for i := 0; i < customPropCount; i++ {
+		if pos+3 > len(data) {
+			return nil, 0, fmt.Errorf("truncated custom property %d", i)
+		}
+		cp := CustomProperty{KeyIndex: data[pos], Type: data[pos+1], Size: data[pos+2]}
+		pos += 3
+		if pos+int(cp.Size) > len(data) {
+			return nil, 0, fmt.Errorf("truncated custom property %d value", i)
+		}
+		cp.Value = data[pos : pos+int(cp.Size)]
+		pos += int(cp.Size)
+		el.CustomProperties = append(el.CustomProperties, cp)
+	}
+
+	for i := 0; i < statePropCount; i++ {
+		if pos+2 > len(data) {
+			return nil, 0, fmt.Errorf("truncated state property set %d", i)
+		}
+		set := StatePropertySet{StateFlags: data[pos]}
+		n := int(data[pos+1])
+		pos += 2
+		for j := 0; j < n; j++ {
+			p, next, err := readProperty(data, pos)
+			if err != nil {
+				return nil, 0, fmt.Errorf("state set %d property %d: %w", i, j, err)
+			}
+			set.Properties = append(set.Properties, p)
+			pos = next
+		}
+		el.StateProperties = append(el.StateProperties, set)
+	}
+
+	for i := 0; i < eventCount; i++ {
+		if pos+2 > len(data) {
+			return nil, 0, fmt.Errorf("truncated event %d", i)
+		}
+		el.Events = append(el.Events, Event{Type: data[pos], CallbackID: data[pos+1]})
+		pos += 2
+	}
+
+	for i := 0; i < animCount; i++ {
+		if pos+2 > len(data) {
+			return nil, 0, fmt.Errorf("truncated animation ref %d", i)
+		}
+		el.AnimationRefs = append(el.AnimationRefs, AnimationRef{AnimationIndex: data[pos], Trigger: data[pos+1]})
+		pos += 2
+	}
+
+	for i := 0; i < childCount; i++ {
+		if pos+2 > len(data) {
+			return nil, 0, fmt.Errorf("truncated child ref %d", i)
+		}
+		el.ChildOffsets = append(el.ChildOffsets, le16(data[pos:pos+2]))
+		pos += 2
+	}
+
+	return el, pos, nil
+}
+
+func readProperty(data []byte, pos int) (Property, int, error) {
+	if pos+3 > len(data) {
+		return Property{}, 0, fmt.Errorf("truncated property header")
+	}
+	p := Property{ID: data[pos], Type: data[pos+1], Size: data[pos+2]}
+	pos += 3
+	if pos+int(p.Size) > len(data) {
+		return Property{}, 0, fmt.Errorf("truncated property value")
+	}
+	p.Value = data[pos : pos+int(p.Size)]
+	pos += int(p.Size)
+	return p, pos, nil
+}
+
+// linkChildren resolves each element's ChildOffsets (relative to its own
+// header) into indices into the flat Elements slice.
+func linkChildren(elements []*Element) {
+	byOffset := make(map[uint32]int, len(elements))
+	for i, el := range elements {
+		byOffset[el.SelfOffset] = i
+	}
+	for _, el := range elements {
+		for _, rel := range el.ChildOffsets {
+			abs := el.SelfOffset + uint32(rel)
+			if idx, ok := byOffset[abs]; ok {
+				el.Children = append(el.Children, idx)
+			}
+		}
+	}
+}
+
+func readStyles(data []byte, offset uint32, count int) ([]Style, error) {
+	out := make([]Style, 0, count)
+	pos := int(offset)
+	for i := 0; i < count; i++ {
+		if pos+3 > len(data) {
+			return nil, fmt.Errorf("truncated style header %d", i)
+		}
+		s := Style{ID: data[pos], NameIndex: data[pos+1]}
+		n := int(data[pos+2])
+		pos += 3
+		for j := 0; j < n; j++ {
+			p, next, err := readProperty(data, pos)
+			if err != nil {
+				return nil, fmt.Errorf("style %d property %d: %w", i, j, err)
+			}
+			s.Properties = append(s.Properties, p)
+			pos = next
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// readComponentTemplate reads a component template rooted at pos: the
+// root element's own header/properties/etc, then -- depth-first,
+// immediately following the root in the file, exactly as many nested
+// element blocks as the root's Child Count (and so on recursively) --
+// its template children. Unlike the main Element Blocks section, a
+// template has no separate total-element-count to read up front and its
+// ChildOffsets are not resolved by byte offset; the template's layout
+// *is* the tree; readComponentTemplate recovers it with plain recursive
+// descent and returns it flattened (root first) with Children reindexed
+// to this template-local slice.
+func readComponentTemplate(data []byte, pos int) ([]*Element, int, error) {
+	root, next, err := readOneElement(data, pos, -1)
+	if err != nil {
+		return nil, 0, err
+	}
+	childCount := len(root.ChildOffsets)
+	elements := []*Element{root}
+	for i := 0; i < childCount; i++ {
+		sub, subNext, err := readComponentTemplate(data, next)
+		if err != nil {
+			return nil, 0, fmt.Errorf("child %d: %w", i, err)
+		}
+		offset := len(elements)
+		for _, e := range sub {
+			for j, c := range e.Children {
+				e.Children[j] = c + offset
+			}
+		}
+		root.Children = append(root.Children, offset)
+		elements = append(elements, sub...)
+		next = subNext
+	}
+	return elements, next, nil
+}
+
+func readComponentDefs(data []byte, offset uint32, count int) ([]ComponentDef, error) {
+	out := make([]ComponentDef, 0, count)
+	pos := int(offset)
+	for i := 0; i < count; i++ {
+		if pos+2 > len(data) {
+			return nil, fmt.Errorf("truncated component def %d", i)
+		}
+		def := ComponentDef{NameIndex: data[pos]}
+		propDefCount := int(data[pos+1])
+		pos += 2
+		for j := 0; j < propDefCount; j++ {
+			if pos+3 > len(data) {
+				return nil, fmt.Errorf("truncated property def %d/%d", i, j)
+			}
+			pd := PropertyDef{NameIndex: data[pos], ValueTypeHint: data[pos+1]}
+			size := int(data[pos+2])
+			pos += 3
+			if pos+size > len(data) {
+				return nil, fmt.Errorf("truncated property def default %d/%d", i, j)
+			}
+			pd.DefaultValue = data[pos : pos+size]
+			pos += size
+			def.PropertyDefs = append(def.PropertyDefs, pd)
+		}
+		elements, next, err := readComponentTemplate(data, pos)
+		if err != nil {
+			return nil, fmt.Errorf("component def %d template: %w", i, err)
+		}
+		def.Elements = elements
+		def.Root = elements[0]
+		pos = next
+		out = append(out, def)
+	}
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package krb
+
+import "testing"
+
+func validHeaderBytes(elementCount uint16) []byte {
+	h := make([]byte, HeaderSize)
+	copy(h[0:4], Magic[:])
+	le := func(b []byte, v uint16) { b[0] = byte(v); b[1] = byte(v >> 8) }
+	le(h[4:6], 5<<8) // version 0.5 (Minor<<8 | Major)
+	le(h[8:10], elementCount)
+	putLE32(h[22:26], HeaderSize) // ElementOffset right after the header
+	return h
+}
+
+func putLE32(b []byte, v uint32) {
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+}
+
+func TestDecodeBytesRejectsTruncatedPropertyCountWithoutPanicking(t *testing.T) {
+	data := validHeaderBytes(1)
+	// One element header claiming 200 properties follow, but no property
+	// data (or even a second element) actually exists in the buffer.
+	elHeader := make([]byte, ElementHeaderSize)
+	elHeader[12] = 200 // Property Count
+	data = append(data, elHeader...)
+
+	_, err := DecodeBytes(data)
+	if err == nil {
+		t.Fatalf("expected an error decoding a truncated file, got nil")
+	}
+}
+
+func TestDecodeBytesReadsV4HeaderLayout(t *testing.T) {
+	h := make([]byte, HeaderSizeV4)
+	copy(h[0:4], Magic[:])
+	le := func(b []byte, v uint16) { b[0] = byte(v); b[1] = byte(v >> 8) }
+	le(h[4:6], 4<<8) // version 0.4 (Minor<<8 | Major)
+	le(h[8:10], 0)
+	putLE32(h[20:24], HeaderSizeV4) // ElementOffset right after the header
+
+	doc, err := DecodeBytes(h)
+	if err != nil {
+		t.Fatalf("DecodeBytes: %v", err)
+	}
+	if doc.Header.Version != 4<<8 {
+		t.Fatalf("Header.Version = %d, want %d", doc.Header.Version, uint16(4<<8))
+	}
+	if doc.Header.ElementOffset != HeaderSizeV4 {
+		t.Fatalf("Header.ElementOffset = %d, want %d (the v0.4 layout's offset for this field)", doc.Header.ElementOffset, HeaderSizeV4)
+	}
+}
+
+func TestDecodeBytesReadsV2HeaderLayout(t *testing.T) {
+	h := make([]byte, HeaderSizeV2)
+	copy(h[0:4], Magic[:])
+	le := func(b []byte, v uint16) { b[0] = byte(v); b[1] = byte(v >> 8) }
+	le(h[4:6], 3<<8) // version 0.3 (Minor<<8 | Major)
+	le(h[8:10], 0)
+	putLE32(h[18:22], HeaderSizeV2) // ElementOffset right after the header
+
+	doc, err := DecodeBytes(h)
+	if err != nil {
+		t.Fatalf("DecodeBytes: %v", err)
+	}
+	if doc.Header.ElementOffset != HeaderSizeV2 {
+		t.Fatalf("Header.ElementOffset = %d, want %d (the v0.2/v0.3 layout's offset for this field)", doc.Header.ElementOffset, HeaderSizeV2)
+	}
+}
+
+func TestDecodeBytesRejectsTooSmallBuffer(t *testing.T) {
+	if _, err := DecodeBytes([]byte{1, 2, 3}); err == nil {
+		t.Fatalf("expected an error for a buffer smaller than the header")
+	}
+}
+
+func TestDecodeBytesRejectsFileShorterThanDeclaredTotalSize(t *testing.T) {
+	data := validHeaderBytes(0)
+	putLE32(data[50:54], uint32(len(data)+100))
+
+	_, err := DecodeBytes(data)
+	if err == nil {
+		t.Fatalf("expected an error when the buffer is shorter than the header's declared Total Size")
+	}
+}
+
+func TestDecodeBytesAllowsTrailingBytesPastTotalSize(t *testing.T) {
+	data := validHeaderBytes(0)
+	putLE32(data[50:54], uint32(len(data)))
+	data = append(data, 0xAA, 0xBB) // trailing bytes the decoder shouldn't care about
+
+	if _, err := DecodeBytes(data); err != nil {
+		t.Fatalf("DecodeBytes: %v", err)
+	}
+}
+
+func TestKeepRawDataOptsInToRetainingTheSourceBuffer(t *testing.T) {
+	data := validHeaderBytes(0)
+
+	doc, err := DecodeBytes(data)
+	if err != nil {
+		t.Fatalf("DecodeBytes: %v", err)
+	}
+	if doc.Raw != nil {
+		t.Fatalf("Raw should be nil without KeepRawData, got %d bytes", len(doc.Raw))
+	}
+
+	doc, err = DecodeBytes(data, KeepRawData())
+	if err != nil {
+		t.Fatalf("DecodeBytes with KeepRawData: %v", err)
+	}
+	if len(doc.Raw) != len(data) {
+		t.Fatalf("Raw = %d bytes, want %d", len(doc.Raw), len(data))
+	}
+}
@@ -0,0 +1,52 @@
+package krb
+
+// ComponentSchema is an application-facing, string-resolved view of a
+// ComponentDef: its name and the properties a usage can set. ComponentDef
+// itself stores these as raw string-table indices (like the rest of this
+// package); ComponentSchema exists so applications introspecting
+// available components -- e.g. a visual editor listing what properties a
+// component accepts -- don't have to resolve those indices by hand.
+type ComponentSchema struct {
+	Name       string
+	Properties []PropertySchema
+}
+
+// PropertySchema is the string-resolved form of a PropertyDef.
+type PropertySchema struct {
+	Name          string
+	ValueTypeHint uint8
+	DefaultValue  []byte
+}
+
+// ComponentSchemas returns every component definition in d as an
+// application-facing schema.
+func (d *Document) ComponentSchemas() []ComponentSchema {
+	out := make([]ComponentSchema, len(d.ComponentDefs))
+	for i, def := range d.ComponentDefs {
+		out[i] = d.componentSchema(def)
+	}
+	return out
+}
+
+// ComponentSchema looks up a single component definition by name and
+// returns its application-facing schema.
+func (d *Document) ComponentSchema(name string) (ComponentSchema, bool) {
+	for _, def := range d.ComponentDefs {
+		if d.String(def.NameIndex) == name {
+			return d.componentSchema(def), true
+		}
+	}
+	return ComponentSchema{}, false
+}
+
+func (d *Document) componentSchema(def ComponentDef) ComponentSchema {
+	props := make([]PropertySchema, len(def.PropertyDefs))
+	for i, p := range def.PropertyDefs {
+		props[i] = PropertySchema{
+			Name:          d.String(p.NameIndex),
+			ValueTypeHint: p.ValueTypeHint,
+			DefaultValue:  p.DefaultValue,
+		}
+	}
+	return ComponentSchema{Name: d.String(def.NameIndex), Properties: props}
+}
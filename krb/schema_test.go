@@ -0,0 +1,37 @@
+package krb
+
+import "testing"
+
+func TestComponentSchemaResolvesStringIndices(t *testing.T) {
+	doc := &Document{
+		Strings: []string{"", "Card", "title", "Untitled"},
+		ComponentDefs: []ComponentDef{
+			{
+				NameIndex: 1,
+				PropertyDefs: []PropertyDef{
+					{NameIndex: 2, ValueTypeHint: ValTypeString, DefaultValue: []byte{3}},
+				},
+			},
+		},
+	}
+
+	schema, ok := doc.ComponentSchema("Card")
+	if !ok {
+		t.Fatalf("ComponentSchema(%q) not found", "Card")
+	}
+	if schema.Name != "Card" {
+		t.Fatalf("schema.Name = %q, want Card", schema.Name)
+	}
+	if len(schema.Properties) != 1 || schema.Properties[0].Name != "title" {
+		t.Fatalf("schema.Properties = %+v, want [{Name: title, ...}]", schema.Properties)
+	}
+
+	if _, ok := doc.ComponentSchema("Missing"); ok {
+		t.Fatalf("expected ComponentSchema to report not-found for an unknown name")
+	}
+
+	all := doc.ComponentSchemas()
+	if len(all) != 1 || all[0].Name != "Card" {
+		t.Fatalf("ComponentSchemas() = %+v, want one Card entry", all)
+	}
+}
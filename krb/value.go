@@ -0,0 +1,10 @@
+package krb
+
+// DecodePercentage decodes a VAL_TYPE_PERCENTAGE value: a little-endian
+// 8.8 fixed-point number where 256 represents 1.0 (100%).
+func DecodePercentage(value []byte) float32 {
+	if len(value) < 2 {
+		return 0
+	}
+	return float32(le16(value)) / 256.0
+}
@@ -0,0 +1,37 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// drawBorders strokes el's four border sides per render.BorderSegments.
+// A BorderStyleSolid/BorderStyleDashed segment is a plain filled
+// rectangle; a BorderStyleDotted segment is drawn as a short thick line
+// along its own long axis instead, so dots come out round rather than
+// square. r.scaleFactor scales dash/dot/gap lengths the same way a
+// window's DPI scale would.
+func (r *Renderer) drawBorders(el *render.RenderElement) {
+	for _, seg := range render.BorderSegments(el, r.scaleFactor) {
+		color := toRaylibColor(seg.Color)
+		if el.BorderStyle != render.BorderStyleDotted {
+			rl.DrawRectangle(int32(seg.X), int32(seg.Y), int32(seg.Width), int32(seg.Height), color)
+			continue
+		}
+		start, end, thickness := dotLine(seg)
+		rl.DrawLineEx(start, end, thickness, color)
+	}
+}
+
+// dotLine turns a dotted BorderSegment (a short rect along one axis,
+// fixed-thickness on the other) into the line DrawLineEx should stroke:
+// a line along the segment's long axis, thick enough to cover its short
+// axis.
+func dotLine(seg render.BorderSegment) (start, end rl.Vector2, thickness float32) {
+	if seg.Width >= seg.Height {
+		y := seg.Y + seg.Height/2
+		return rl.Vector2{X: seg.X, Y: y}, rl.Vector2{X: seg.X + seg.Width, Y: y}, seg.Height
+	}
+	x := seg.X + seg.Width/2
+	return rl.Vector2{X: x, Y: seg.Y}, rl.Vector2{X: x, Y: seg.Y + seg.Height}, seg.Width
+}
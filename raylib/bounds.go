@@ -0,0 +1,46 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// ElementBounds returns el's post-layout screen rect as a raylib
+// Rectangle, for custom drawing code, tooltips or external automation
+// built on this backend. ok is false if el hasn't been laid out yet or
+// isn't currently visible.
+func (r *Renderer) ElementBounds(el *render.RenderElement) (rl.Rectangle, bool) {
+	bounds, ok := render.ElementBounds(el)
+	if !ok {
+		return rl.Rectangle{}, false
+	}
+	return toRaylibRect(bounds), true
+}
+
+// ContentBounds is ElementBounds inset by el's padding -- the box el's
+// own children are arranged within.
+func (r *Renderer) ContentBounds(el *render.RenderElement) (rl.Rectangle, bool) {
+	bounds, ok := render.ContentBounds(el)
+	if !ok {
+		return rl.Rectangle{}, false
+	}
+	return toRaylibRect(bounds), true
+}
+
+// DocumentBounds returns the union of every root in roots' ElementBounds
+// as a raylib Rectangle -- the full on-screen extent of the laid-out
+// document. Useful for fitting the window to content, computing scroll
+// extents, or cropping a Screenshot to just the rendered UI instead of
+// the whole window. ok is false if roots is empty or none of them
+// currently have valid bounds.
+func (r *Renderer) DocumentBounds(roots []*render.RenderElement) (rl.Rectangle, bool) {
+	bounds, ok := render.DocumentBounds(roots)
+	if !ok {
+		return rl.Rectangle{}, false
+	}
+	return toRaylibRect(bounds), true
+}
+
+func toRaylibRect(r render.Rect) rl.Rectangle {
+	return rl.Rectangle{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}
+}
@@ -0,0 +1,20 @@
+package raylib
+
+// clampDimension only does integer arithmetic on a plain float32, never
+// touching the GL context, so it's safe to exercise here without a
+// window -- see cursor_test.go for the precedent on testing this
+// package's GL-independent logic.
+
+import "testing"
+
+func TestClampDimensionFloorsNegativeAtZero(t *testing.T) {
+	if got := clampDimension(-40); got != 0 {
+		t.Fatalf("clampDimension(-40) = %d, want 0", got)
+	}
+}
+
+func TestClampDimensionPassesThroughNonNegative(t *testing.T) {
+	if got := clampDimension(120); got != 120 {
+		t.Fatalf("clampDimension(120) = %d, want 120", got)
+	}
+}
@@ -0,0 +1,49 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// hoveredCursor resolves the native raylib cursor shape to show for the
+// currently hovered element, falling back to CursorPointer for an
+// IsInteractive element that didn't declare an explicit PropIDCursor, and
+// MouseCursorDefault when nothing is hovered.
+func hoveredCursor(hovered *render.RenderElement) rl.MouseCursor {
+	if hovered == nil {
+		return rl.MouseCursorDefault
+	}
+	cursor := hovered.Cursor
+	if cursor == render.CursorDefault && hovered.IsInteractive {
+		cursor = render.CursorPointer
+	}
+	return toRaylibCursor(cursor)
+}
+
+// toRaylibCursor maps a render.Cursor onto raylib's native cursor shapes.
+// raylib has no distinct "wait" or "help" cursor, so both fall back to
+// MouseCursorDefault like CursorDefault itself.
+func toRaylibCursor(c render.Cursor) rl.MouseCursor {
+	switch c {
+	case render.CursorPointer:
+		return rl.MouseCursorPointingHand
+	case render.CursorText:
+		return rl.MouseCursorIBeam
+	case render.CursorCrosshair:
+		return rl.MouseCursorCrosshair
+	case render.CursorMove:
+		return rl.MouseCursorResizeAll
+	case render.CursorResizeNS:
+		return rl.MouseCursorResizeNS
+	case render.CursorResizeEW:
+		return rl.MouseCursorResizeEW
+	case render.CursorResizeNESW:
+		return rl.MouseCursorResizeNESW
+	case render.CursorResizeNWSE:
+		return rl.MouseCursorResizeNWSE
+	case render.CursorNotAllowed:
+		return rl.MouseCursorNotAllowed
+	default:
+		return rl.MouseCursorDefault
+	}
+}
@@ -0,0 +1,62 @@
+package raylib
+
+// hoveredCursor and toRaylibCursor only touch render.RenderElement and
+// rl's MouseCursor constants (plain ints), never the GL context, so
+// they're safe to exercise here without a window -- see resize_test.go
+// for the precedent on testing this package's GL-independent logic.
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+func TestHoveredCursorNoneHoveredIsDefault(t *testing.T) {
+	if got := hoveredCursor(nil); got != rl.MouseCursorDefault {
+		t.Fatalf("hoveredCursor(nil) = %v, want MouseCursorDefault", got)
+	}
+}
+
+func TestHoveredCursorInteractiveFallsBackToPointer(t *testing.T) {
+	el := &render.RenderElement{IsInteractive: true}
+	if got := hoveredCursor(el); got != rl.MouseCursorPointingHand {
+		t.Fatalf("hoveredCursor(interactive, no explicit cursor) = %v, want MouseCursorPointingHand", got)
+	}
+}
+
+func TestHoveredCursorExplicitCursorWinsOverInteractiveFallback(t *testing.T) {
+	el := &render.RenderElement{IsInteractive: true, Cursor: render.CursorText}
+	if got := hoveredCursor(el); got != rl.MouseCursorIBeam {
+		t.Fatalf("hoveredCursor = %v, want MouseCursorIBeam", got)
+	}
+}
+
+func TestHoveredCursorNonInteractiveNoExplicitCursorIsDefault(t *testing.T) {
+	el := &render.RenderElement{}
+	if got := hoveredCursor(el); got != rl.MouseCursorDefault {
+		t.Fatalf("hoveredCursor(plain element) = %v, want MouseCursorDefault", got)
+	}
+}
+
+func TestToRaylibCursorMapsEachShape(t *testing.T) {
+	cases := map[render.Cursor]rl.MouseCursor{
+		render.CursorDefault:    rl.MouseCursorDefault,
+		render.CursorPointer:    rl.MouseCursorPointingHand,
+		render.CursorText:       rl.MouseCursorIBeam,
+		render.CursorCrosshair:  rl.MouseCursorCrosshair,
+		render.CursorMove:       rl.MouseCursorResizeAll,
+		render.CursorResizeNS:   rl.MouseCursorResizeNS,
+		render.CursorResizeEW:   rl.MouseCursorResizeEW,
+		render.CursorResizeNESW: rl.MouseCursorResizeNESW,
+		render.CursorResizeNWSE: rl.MouseCursorResizeNWSE,
+		render.CursorWait:       rl.MouseCursorDefault,
+		render.CursorHelp:       rl.MouseCursorDefault,
+		render.CursorNotAllowed: rl.MouseCursorNotAllowed,
+	}
+	for in, want := range cases {
+		if got := toRaylibCursor(in); got != want {
+			t.Fatalf("toRaylibCursor(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package raylib
+
+import "github.com/waozixyz/kryon/render"
+
+// DrawHook lets application code customize how an expanded component's
+// root element is drawn, layered around drawElement's own standard
+// drawing (background, image, children) for that element. It's bound to
+// a component name with RegisterDrawHook, the same way
+// render.RegisterHandler binds a callback function to a KRB event's
+// callback name.
+type DrawHook interface {
+	// Draw runs before standard drawing, with the element's layout
+	// (X/Y/Width/Height) already resolved. Returning true skips standard
+	// drawing for this element; children are drawn regardless.
+	Draw(el *render.RenderElement) (skipStandardDraw bool)
+}
+
+// PostDrawHook is an optional extension to DrawHook: a hook that also
+// implements it is called after the element's standard drawing and all
+// of its children have been drawn, e.g. to paint a decoration on top of
+// already-drawn content (a selection outline, a badge). It still runs
+// even if Draw returned skipStandardDraw -- there's always something
+// already drawn underneath by the time PostDraw runs, whether that's
+// standard drawing or children. Like the rest of drawElement, it runs
+// inside the element's own clip scissor when el.ClipToParent is set.
+type PostDrawHook interface {
+	PostDraw(el *render.RenderElement)
+}
+
+// drawHooks maps a component name (see render.ComponentName) to the
+// hook registered for it.
+var drawHooks = map[string]DrawHook{}
+
+// RegisterDrawHook binds hook to componentName, replacing any hook
+// previously registered for that name. Passing a nil hook removes it.
+// It affects every element expanded from that component, in every
+// Renderer, the same process-wide scope render.RegisterHandler uses for
+// event callbacks.
+func RegisterDrawHook(componentName string, hook DrawHook) {
+	if hook == nil {
+		delete(drawHooks, componentName)
+		return
+	}
+	drawHooks[componentName] = hook
+}
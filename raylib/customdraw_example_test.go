@@ -0,0 +1,43 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// tabBarUnderline demonstrates PostDrawHook: it draws a 3px
+// accent-colored underline beneath a TabBar's active tab, on top of the
+// tabs' own already-drawn content, without having to reimplement any of
+// TabBar's standard child drawing itself. Draw is a no-op here since the
+// underline only needs to be painted after the tabs are drawn.
+type tabBarUnderline struct{}
+
+func (tabBarUnderline) Draw(el *render.RenderElement) (skipStandardDraw bool) {
+	return false
+}
+
+func (tabBarUnderline) PostDraw(el *render.RenderElement) {
+	active := activeTab(el)
+	if active == nil {
+		return
+	}
+	const underlineHeight = 3
+	y := int32(active.Y + active.Height - underlineHeight)
+	rl.DrawRectangle(int32(active.X), y, int32(active.Width), underlineHeight, toRaylibColor(el.FgColor))
+}
+
+// activeTab is a stand-in for however a real TabBar component marks its
+// active child (e.g. an "active_index" custom property); this example
+// only needs something that resolves to one of the tabs.
+func activeTab(el *render.RenderElement) *render.RenderElement {
+	if len(el.Children) == 0 {
+		return nil
+	}
+	return el.Children[0]
+}
+
+// ExampleRegisterDrawHook shows binding a PostDrawHook to every element
+// expanded from the "TabBar" component.
+func ExampleRegisterDrawHook() {
+	RegisterDrawHook("TabBar", tabBarUnderline{})
+}
@@ -0,0 +1,30 @@
+package raylib
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/render"
+)
+
+// RegisterDrawHook's map bookkeeping has no GL/raylib dependency, unlike
+// the rest of this package, so it's tested directly rather than left
+// untested like the GL-dependent drawing code (see resize_test.go for
+// the same reasoning).
+
+type noopDrawHook struct{}
+
+func (noopDrawHook) Draw(el *render.RenderElement) (skipStandardDraw bool) { return false }
+
+func TestRegisterDrawHookNilRemoves(t *testing.T) {
+	t.Cleanup(func() { delete(drawHooks, "Test") })
+
+	RegisterDrawHook("Test", noopDrawHook{})
+	if _, ok := drawHooks["Test"]; !ok {
+		t.Fatalf("expected a hook registered for %q", "Test")
+	}
+
+	RegisterDrawHook("Test", nil)
+	if _, ok := drawHooks["Test"]; ok {
+		t.Fatalf("expected RegisterDrawHook(name, nil) to remove the hook")
+	}
+}
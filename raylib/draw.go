@@ -0,0 +1,197 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/krb"
+	"github.com/waozixyz/kryon/render"
+)
+
+// Draw renders one frame of roots. It's a no-op if called before Init or
+// after Cleanup, rather than driving raylib calls with no window/GL
+// context behind them.
+//
+// If damage tracking is enabled (see render.EnableDamageTracking), Draw
+// consults it instead of always drawing the full tree: an empty damage
+// list skips the frame entirely (leaving the previous frame on screen,
+// for an idle low-power UI), and a non-empty one is redrawn scissored to
+// just the damaged union, leaving everything outside it untouched. A
+// window resize invalidates that shortcut for one frame, since the
+// tracker's rects were computed against the old size.
+func (r *Renderer) Draw(roots []*render.RenderElement) {
+	if !r.windowOpen {
+		return
+	}
+	render.AdvanceAnimations(roots, frameTime())
+	r.advanceVideos(frameTime())
+
+	screenW, screenH := int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight())
+	resized := r.lastScreenW != 0 && (screenW != r.lastScreenW || screenH != r.lastScreenH)
+	r.lastScreenW, r.lastScreenH = screenW, screenH
+
+	if damage := render.ActiveDamageTracker(); damage != nil && !resized {
+		if damage.Empty() {
+			damage.RecordFrame(render.FrameSkipped)
+			return
+		}
+		if clip, ok := damage.Union(); ok {
+			r.drawPartial(roots, clip)
+			damage.RecordFrame(render.FramePartial)
+			damage.Reset()
+			return
+		}
+	}
+
+	rl.BeginDrawing()
+	rl.ClearBackground(rl.RayWhite)
+	for _, root := range roots {
+		r.drawElement(root)
+	}
+	r.drawTooltipIfHovered()
+	rl.EndDrawing()
+	if damage := render.ActiveDamageTracker(); damage != nil {
+		damage.RecordFrame(render.FrameFull)
+		damage.Reset()
+	}
+}
+
+// drawPartial redraws roots scissored to clip, the damaged region
+// reported by the active DamageTracker, leaving whatever the previous
+// frame drew outside it in place. If SetDamageDebugColor has set a
+// non-zero-alpha color, it also outlines clip so the restricted region
+// can be seen during development.
+func (r *Renderer) drawPartial(roots []*render.RenderElement, clip render.Rect) {
+	x, y := int32(clip.X), int32(clip.Y)
+	w, h := clampDimension(clip.Width), clampDimension(clip.Height)
+	rl.BeginDrawing()
+	rl.BeginScissorMode(x, y, w, h)
+	rl.ClearBackground(rl.RayWhite)
+	for _, root := range roots {
+		r.drawElement(root)
+	}
+	if r.damageDebugColor.A > 0 {
+		rl.DrawRectangleLines(x, y, w, h, toRaylibColor(r.damageDebugColor))
+	}
+	rl.EndScissorMode()
+	r.drawTooltipIfHovered()
+	rl.EndDrawing()
+}
+
+// SetDamageDebugColor outlines each partial frame's scissored region in
+// c, for visually verifying damage tracking during development. Passing
+// the zero Color (the default) disables the outline.
+func (r *Renderer) SetDamageDebugColor(c render.Color) {
+	r.damageDebugColor = c
+}
+
+// DrawSubtree renders only root and its descendants, leaving the rest of
+// the last drawn frame's content in place outside that region. Useful
+// for redrawing a single dirty popup/modal without walking the whole tree.
+// Like Draw, it's a no-op before Init or after Cleanup.
+func (r *Renderer) DrawSubtree(root *render.RenderElement) {
+	if !r.windowOpen {
+		return
+	}
+	rl.BeginDrawing()
+	r.drawElement(root)
+	rl.EndDrawing()
+}
+
+func (r *Renderer) drawElement(el *render.RenderElement) {
+	if !el.EffectiveVisible {
+		return
+	}
+	if el.ClipToParent && el.Parent != nil {
+		p := el.Parent
+		rl.BeginScissorMode(int32(p.X), int32(p.Y), clampDimension(p.Width), clampDimension(p.Height))
+		defer rl.EndScissorMode()
+	}
+
+	var hook DrawHook
+	if name, ok := render.ComponentName(el); ok {
+		hook = drawHooks[name]
+	}
+
+	skipStandard := false
+	if hook != nil {
+		skipStandard = hook.Draw(el)
+	}
+
+	if !skipStandard {
+		bg := toRaylibColor(el.BgColor)
+		if el.BgColor.A > 0 {
+			rl.DrawRectangle(int32(el.X), int32(el.Y), int32(el.Width), int32(el.Height), bg)
+		}
+		r.drawBorders(el)
+		if el.Selectable && el.SelectionStart != el.SelectionEnd {
+			r.drawSelectionHighlight(el)
+		}
+		if el.ImagePath != "" {
+			var tex rl.Texture2D
+			switch {
+			case el.Type == krb.ElemTypeVideo:
+				tex = r.videoTexture(el)
+			case el.Grayscale:
+				tex = r.grayscaleTexture(el.ImagePath)
+			default:
+				tex = r.cachedTexture(el.ImagePath)
+			}
+			frame := render.SpriteFrameRect(el, float32(tex.Width), float32(tex.Height))
+			srcRect, destRect := render.ImageRects(el, frame.Width, frame.Height)
+			srcRect.X += frame.X
+			srcRect.Y += frame.Y
+			src := toRaylibRect(srcRect)
+			// raylib mirrors a texture by sourcing a negative-size rect
+			// offset to the far edge, not via a separate flip flag.
+			if el.ImageFlipH {
+				src.X, src.Width = src.X+src.Width, -src.Width
+			}
+			if el.ImageFlipV {
+				src.Y, src.Height = src.Y+src.Height, -src.Height
+			}
+			dest := toRaylibRect(destRect)
+			dest.X += el.X
+			dest.Y += el.Y
+			rl.DrawTexturePro(tex, src, dest, rl.Vector2{}, 0, toRaylibColor(render.ResolvedImageTint(el)))
+		}
+	}
+
+	for _, c := range el.Children {
+		r.drawElement(c)
+	}
+
+	if post, ok := hook.(PostDrawHook); ok {
+		post.PostDraw(el)
+	}
+}
+
+// cachedTexture returns the texture for path, loading and caching it the
+// first time it's drawn.
+func (r *Renderer) cachedTexture(path string) rl.Texture2D {
+	if r.textures == nil {
+		r.textures = map[string]rl.Texture2D{}
+	}
+	if tex, ok := r.textures[path]; ok {
+		return tex
+	}
+	tex := r.loadTexture(path)
+	r.textures[path] = tex
+	return tex
+}
+
+// clampDimension converts a post-layout width/height to int32 for a
+// raylib call, floored at 0. render.Layout already sanitizes NaN/Inf out
+// of the tree, but a negative content rect (e.g. padding wider than its
+// own element) is a legal, finite layout result -- without this clamp it
+// would pass a negative int32 into BeginScissorMode, which raylib's C
+// binding treats as an enormous unsigned width/height rather than "clip
+// everything".
+func clampDimension(v float32) int32 {
+	if v < 0 {
+		return 0
+	}
+	return int32(v)
+}
+
+func toRaylibColor(c render.Color) rl.Color {
+	return rl.Color{R: c.R, G: c.G, B: c.B, A: c.A}
+}
@@ -0,0 +1,43 @@
+package raylib
+
+import (
+	"path/filepath"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// FileDropHook is called from PollEvents after a dropped .krb file has
+// been reloaded, whether or not that reload succeeded. newRoots and cfg
+// are the zero value on error. Since Reload doesn't swap anything in
+// place (see Renderer.Reload), the hook is where an app driving its own
+// loop (rather than RunLoop -- see its doc comment) picks up newRoots
+// and starts passing it to PollEvents/Draw instead of its old tree.
+type FileDropHook func(newRoots []*render.RenderElement, cfg render.WindowConfig, err error)
+
+// SetFileDropHook installs fn to run whenever PollEvents reloads a
+// dropped .krb file. Passing nil removes it, the same convention as
+// SetUpdateHook.
+func (r *Renderer) SetFileDropHook(fn FileDropHook) {
+	r.fileDropHook = fn
+}
+
+// handleFileDrop checks for a file the OS dropped onto the window this
+// frame and, if one of them is a .krb file, reloads roots from it and
+// reports the result to r.fileDropHook. Only the first .krb among a
+// multi-file drop is used -- an app that needs to load several at once
+// should call render.Reload/Renderer.Reload itself from within the hook.
+func (r *Renderer) handleFileDrop(roots []*render.RenderElement) {
+	if r.fileDropHook == nil || !rl.IsFileDropped() {
+		return
+	}
+	for _, path := range rl.LoadDroppedFiles() {
+		if !strings.EqualFold(filepath.Ext(path), ".krb") {
+			continue
+		}
+		newRoots, cfg, err := r.Reload(path, roots)
+		r.fileDropHook(newRoots, cfg, err)
+		return
+	}
+}
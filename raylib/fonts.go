@@ -0,0 +1,103 @@
+package raylib
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// fontRun is a contiguous piece of text selected to draw with a single
+// resolved font; see selectFontRuns.
+type fontRun struct {
+	Text string
+	Font rl.Font
+}
+
+// LoadCustomFont loads the font at path and registers it under name,
+// making it selectable by name (e.g. from a "font" custom property) and
+// eligible to be named in SetFallbackFonts. fontSize sets the base glyph
+// rasterization size.
+func (r *Renderer) LoadCustomFont(name, path string, fontSize int32) error {
+	font := rl.LoadFontEx(path, fontSize, nil, 0)
+	if font.Texture.ID == 0 {
+		return fmt.Errorf("raylib: failed to load font %q from %s", name, path)
+	}
+	if r.fonts == nil {
+		r.fonts = map[string]rl.Font{}
+	}
+	r.fonts[name] = font
+	return nil
+}
+
+// SetFallbackFonts configures the ordered chain of registered font names
+// consulted, after a run's own font, when a character isn't present in
+// it -- e.g. a CJK or emoji fallback behind a Latin primary font. Names
+// not registered via LoadCustomFont are skipped.
+func (r *Renderer) SetFallbackFonts(names []string) {
+	r.fallbackFonts = names
+}
+
+// resolveFont returns primaryName's font (raylib's built-in default if
+// primaryName is empty or unregistered) for drawing ch, substituting the
+// first font in the fallback chain that actually has a glyph for ch.
+func (r *Renderer) resolveFont(primaryName string, ch rune) rl.Font {
+	primary, ok := r.fonts[primaryName]
+	if !ok {
+		primary = rl.GetFontDefault()
+	}
+	if hasGlyph(primary, ch) {
+		return primary
+	}
+	for _, name := range r.fallbackFonts {
+		if f, ok := r.fonts[name]; ok && hasGlyph(f, ch) {
+			return f
+		}
+	}
+	return primary
+}
+
+// selectFontRuns splits text into consecutive runs that each resolve to
+// the same font via resolveFont, so each can be drawn with
+// rl.DrawTextEx using the font that actually covers it instead of one
+// font -- and its silent missing-glyph boxes/drops -- for the whole run.
+func (r *Renderer) selectFontRuns(primaryName, text string) []fontRun {
+	var runs []fontRun
+	var cur rl.Font
+	var curText []rune
+	have := false
+
+	flush := func() {
+		if have && len(curText) > 0 {
+			runs = append(runs, fontRun{Text: string(curText), Font: cur})
+		}
+		curText = nil
+	}
+
+	for _, ch := range text {
+		f := r.resolveFont(primaryName, ch)
+		if !have || f.Texture.ID != cur.Texture.ID {
+			flush()
+			cur = f
+			have = true
+		}
+		curText = append(curText, ch)
+	}
+	flush()
+	return runs
+}
+
+// hasGlyph reports whether font has a rasterized glyph for ch, as
+// opposed to raylib's usual behavior of silently substituting a default
+// glyph (or dropping the character) for one it doesn't have.
+func hasGlyph(font rl.Font, ch rune) bool {
+	n := int(font.GlyphCount)
+	if n > len(font.Glyphs) {
+		n = len(font.Glyphs)
+	}
+	for _, g := range font.Glyphs[:n] {
+		if g.Value == int32(ch) {
+			return true
+		}
+	}
+	return false
+}
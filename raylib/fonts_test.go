@@ -0,0 +1,84 @@
+package raylib
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// hasGlyph/resolveFont/selectFontRuns only inspect already-loaded
+// rl.Font struct fields, with no GL calls of their own, so they're
+// tested directly against hand-built fonts rather than left untested
+// with the rest of this package's drawing code.
+
+func glyphFont(textureID uint32, codepoints ...rune) rl.Font {
+	glyphs := make([]rl.GlyphInfo, len(codepoints))
+	for i, c := range codepoints {
+		glyphs[i] = rl.GlyphInfo{Value: int32(c)}
+	}
+	return rl.Font{
+		Texture:    rl.Texture2D{ID: textureID},
+		GlyphCount: int32(len(glyphs)),
+		Glyphs:     glyphs,
+	}
+}
+
+func TestHasGlyph(t *testing.T) {
+	f := glyphFont(1, 'a', 'b', 'c')
+	if !hasGlyph(f, 'b') {
+		t.Fatalf("expected font to have glyph 'b'")
+	}
+	if hasGlyph(f, '漢') {
+		t.Fatalf("did not expect font to have glyph '漢'")
+	}
+}
+
+func TestResolveFontFallsBackWhenPrimaryLacksGlyph(t *testing.T) {
+	var r Renderer
+	r.fonts = map[string]rl.Font{
+		"latin": glyphFont(1, 'a', 'b'),
+		"cjk":   glyphFont(2, '漢'),
+	}
+	r.SetFallbackFonts([]string{"cjk"})
+
+	got := r.resolveFont("latin", 'a')
+	if got.Texture.ID != 1 {
+		t.Fatalf("expected primary font for covered glyph, got texture %d", got.Texture.ID)
+	}
+
+	got = r.resolveFont("latin", '漢')
+	if got.Texture.ID != 2 {
+		t.Fatalf("expected fallback font for uncovered glyph, got texture %d", got.Texture.ID)
+	}
+}
+
+func TestResolveFontUnregisteredFallbackIsSkipped(t *testing.T) {
+	var r Renderer
+	r.fonts = map[string]rl.Font{"latin": glyphFont(1, 'a')}
+	r.SetFallbackFonts([]string{"missing"})
+
+	got := r.resolveFont("latin", '漢')
+	if got.Texture.ID != 1 {
+		t.Fatalf("expected to fall back to primary font when chain is exhausted, got texture %d", got.Texture.ID)
+	}
+}
+
+func TestSelectFontRunsSplitsOnFontChange(t *testing.T) {
+	var r Renderer
+	r.fonts = map[string]rl.Font{
+		"latin": glyphFont(1, 'h', 'i', ' '),
+		"cjk":   glyphFont(2, '漢', '字'),
+	}
+	r.SetFallbackFonts([]string{"cjk"})
+
+	runs := r.selectFontRuns("latin", "hi 漢字")
+	if len(runs) != 2 {
+		t.Fatalf("got %d runs, want 2: %+v", len(runs), runs)
+	}
+	if runs[0].Text != "hi " || runs[0].Font.Texture.ID != 1 {
+		t.Fatalf("run 0 = %+v, want text %q on texture 1", runs[0], "hi ")
+	}
+	if runs[1].Text != "漢字" || runs[1].Font.Texture.ID != 2 {
+		t.Fatalf("run 1 = %+v, want text %q on texture 2", runs[1], "漢字")
+	}
+}
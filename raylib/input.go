@@ -0,0 +1,71 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// sampleInput reads raylib's global input state into a render.InputState.
+// This is PollEvents' default source; InjectInput substitutes a
+// caller-supplied one instead.
+func sampleInput() render.InputState {
+	pos := rl.GetMousePosition()
+	state := render.InputState{
+		MouseX:   pos.X,
+		MouseY:   pos.Y,
+		Wheel:    rl.GetMouseWheelMove(),
+		KeysDown: make(map[string]bool, len(keyNames)),
+		Shift:    rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift),
+		Ctrl:     rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyRightControl),
+		Alt:      rl.IsKeyDown(rl.KeyLeftAlt) || rl.IsKeyDown(rl.KeyRightAlt),
+		Meta:     rl.IsKeyDown(rl.KeyLeftSuper) || rl.IsKeyDown(rl.KeyRightSuper),
+	}
+	for rlButton, button := range mouseButtons {
+		state.MouseDown[button] = rl.IsMouseButtonDown(rlButton)
+	}
+	for key, name := range keyNames {
+		if rl.IsKeyDown(key) {
+			state.KeysDown[name] = true
+		}
+	}
+	return state
+}
+
+// InjectInput substitutes state for PollEvents' next call instead of
+// sampling raylib's own input globals. This is what a render-to-texture
+// embedding uses to feed coordinates it has already transformed into its
+// own space, and what tests use to drive synthetic input sequences
+// against render.InputDispatcher without a real window.
+func (r *Renderer) InjectInput(state render.InputState) {
+	r.injected = &state
+}
+
+// PollEvents samples one frame of input -- from whatever InjectInput last
+// supplied, or raylib's own globals otherwise -- and dispatches it
+// against roots via an render.InputDispatcher, which hit-tests and runs
+// the click/hover/focus state machines. Sampling always happens, even
+// while r is paused (see SetPaused), so the window keeps pumping; only
+// dispatch is skipped. It's a no-op before Init or after Cleanup.
+//
+// It also checks for an OS-level file drop and, if a FileDropHook is
+// installed (see SetFileDropHook) and one of the dropped files is a
+// .krb, reloads roots from it; this happens even while paused, the same
+// as input sampling.
+func (r *Renderer) PollEvents(roots []*render.RenderElement) {
+	if !r.windowOpen {
+		return
+	}
+	r.handleFileDrop(roots)
+	state := sampleInput()
+	if r.injected != nil {
+		state = *r.injected
+		r.injected = nil
+	}
+	if r.paused {
+		return
+	}
+	r.dispatcher.Dispatch(roots, state)
+	r.dispatcher.AdvanceHover(frameTime())
+	r.updateSelection(r.dispatcher.Hovered, state)
+	rl.SetMouseCursor(hoveredCursor(r.dispatcher.Hovered))
+}
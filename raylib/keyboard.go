@@ -0,0 +1,26 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// keyNames maps the raylib key constants Kryon cares about for shortcuts
+// to the backend-independent names render.DispatchKey expects. It's not
+// exhaustive -- just the keys apps commonly bind shortcuts to -- since an
+// unmapped key is simply never routed to DispatchKey.
+var keyNames = map[int32]string{
+	rl.KeyA: "a", rl.KeyB: "b", rl.KeyC: "c", rl.KeyD: "d", rl.KeyE: "e",
+	rl.KeyF: "f", rl.KeyG: "g", rl.KeyH: "h", rl.KeyI: "i", rl.KeyJ: "j",
+	rl.KeyK: "k", rl.KeyL: "l", rl.KeyM: "m", rl.KeyN: "n", rl.KeyO: "o",
+	rl.KeyP: "p", rl.KeyQ: "q", rl.KeyR: "r", rl.KeyS: "s", rl.KeyT: "t",
+	rl.KeyU: "u", rl.KeyV: "v", rl.KeyW: "w", rl.KeyX: "x", rl.KeyY: "y",
+	rl.KeyZ:    "z",
+	rl.KeyZero: "0", rl.KeyOne: "1", rl.KeyTwo: "2", rl.KeyThree: "3",
+	rl.KeyFour: "4", rl.KeyFive: "5", rl.KeySix: "6", rl.KeySeven: "7",
+	rl.KeyEight: "8", rl.KeyNine: "9",
+	rl.KeyEnter: "enter", rl.KeyEscape: "escape", rl.KeyTab: "tab",
+	rl.KeySpace: "space", rl.KeyBackspace: "backspace", rl.KeyDelete: "delete",
+	rl.KeyUp: "up", rl.KeyDown: "down", rl.KeyLeft: "left", rl.KeyRight: "right",
+	rl.KeyKpAdd: "plus", rl.KeyEqual: "plus",
+	rl.KeyKpSubtract: "minus", rl.KeyMinus: "minus",
+}
@@ -0,0 +1,62 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// UpdateHook is called once per frame, before Draw, with the real frame
+// delta in seconds. It runs on the render goroutine and must not block --
+// a slow hook delays the next PollEvents. Any time-based feature
+// (ToastManager.Update is the current example) should be driven from
+// here, rather than sampling its own clock, so a fake clock substituted
+// via frameTime advances every such feature in lockstep for tests.
+type UpdateHook func(dt float32)
+
+// SetUpdateHook installs fn to run once per RunLoop iteration. Passing
+// nil removes it.
+func (r *Renderer) SetUpdateHook(fn UpdateHook) {
+	r.updateHook = fn
+}
+
+// frameTime returns the elapsed time, in seconds, since the previous
+// frame. It's an unexported seam over rl.GetFrameTime so RunLoop's
+// ordering can be exercised with a fake, deterministic clock instead of
+// a real one.
+var frameTime = rl.GetFrameTime
+
+// now returns seconds elapsed since InitWindow. It's an unexported seam
+// over rl.GetTime, the same way frameTime is over rl.GetFrameTime, so
+// resizeDebouncer's timing can be driven from a fake clock in tests.
+var now = rl.GetTime
+
+// RunLoop drives the render loop until the window is asked to close
+// (its close button, Alt+F4, ...), calling, in this order, every frame:
+// PollEvents, the update hook (if one is set) with the real frame delta,
+// render.Layout against the current window size, then Draw. render.Layout
+// is cheap to call unconditionally -- it only redoes flow math for
+// subtrees something actually marked dirty -- except during an active
+// interactive resize, where r.debouncer (see resize.go, configured from
+// WindowConfig.ResizeDebounceMs) throttles it to at most once per
+// interval, with one final exact layout once the size stabilizes; frames
+// it skips just redraw the previous layout. Callers needing a different
+// ordering -- e.g. multiple windows, or their own layout pass between
+// update and draw -- should call PollEvents/Draw directly instead of
+// RunLoop. It returns immediately if called before Init or after
+// Cleanup.
+func (r *Renderer) RunLoop(roots []*render.RenderElement) {
+	if !r.windowOpen {
+		return
+	}
+	for !rl.WindowShouldClose() {
+		r.PollEvents(roots)
+		if r.updateHook != nil {
+			r.updateHook(frameTime())
+		}
+		screenW, screenH := rl.GetScreenWidth(), rl.GetScreenHeight()
+		if r.debouncer.shouldLayout(screenW, screenH, now()) {
+			render.Layout(roots, float32(screenW), float32(screenH))
+		}
+		r.Draw(roots)
+	}
+}
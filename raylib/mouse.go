@@ -0,0 +1,14 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// mouseButtons maps the raylib mouse button constants Kryon discriminates
+// between to their render.MouseButton equivalent.
+var mouseButtons = map[int32]render.MouseButton{
+	rl.MouseButtonLeft:   render.MouseButtonLeft,
+	rl.MouseButtonRight:  render.MouseButtonRight,
+	rl.MouseButtonMiddle: render.MouseButtonMiddle,
+}
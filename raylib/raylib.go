@@ -0,0 +1,211 @@
+// Package raylib implements render.Renderer on top of raylib-go, the
+// reference desktop backend for Kryon. Window/lifecycle management lives
+// in this file; drawing is in draw.go, screen capture in screenshot.go,
+// and texture loading/caching in textures.go.
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// Renderer draws a render.RenderElement tree with raylib.
+type Renderer struct {
+	windowOpen bool
+
+	// maxTextureDimension caps loaded texture width/height; see
+	// SetMaxTextureDimension in textures.go.
+	maxTextureDimension int32
+
+	// textures caches loaded textures by ImagePath so repeated draws of
+	// the same image don't reload it from disk every frame.
+	textures map[string]rl.Texture2D
+
+	// grayTextures caches desaturated copies of textures by ImagePath,
+	// for elements with Grayscale set; see grayscaleTexture in
+	// textures.go. Kept separate from textures so producing one never
+	// mutates the shared, possibly-still-in-use original.
+	grayTextures map[string]rl.Texture2D
+
+	// missingTexture is the placeholder drawn in place of an image that
+	// failed to load; see placeholderTexture in textures.go. Lazily
+	// created so a Renderer that never hits a missing image never pays
+	// for it.
+	missingTexture    rl.Texture2D
+	hasMissingTexture bool
+
+	// paused freezes event dispatch; see SetPaused.
+	paused bool
+
+	// dispatcher carries click/hover/focus state between PollEvents
+	// calls; see render.InputDispatcher.
+	dispatcher render.InputDispatcher
+
+	// injected, when non-nil, is consumed by the next PollEvents call
+	// instead of sampling raylib's own input globals; see InjectInput.
+	injected *render.InputState
+
+	// updateHook, when non-nil, is called once per RunLoop iteration with
+	// the frame delta; see SetUpdateHook.
+	updateHook UpdateHook
+
+	// fileDropHook, when non-nil, is called from PollEvents after a
+	// dropped .krb file has been reloaded; see SetFileDropHook.
+	fileDropHook FileDropHook
+
+	// allowRemoteResources gates fetching image resources named as
+	// http(s) URLs over the network; see SetAllowRemoteResources.
+	allowRemoteResources bool
+
+	// selecting is the Selectable element currently being drag-selected,
+	// if any; see updateSelection in selection.go.
+	selecting *render.RenderElement
+
+	// prevLeftDown is last frame's left mouse button state, so
+	// updateSelection can tell a fresh press from an ongoing drag.
+	prevLeftDown bool
+
+	// resizeDebounce is cfg.ResizeDebounceMs from Init, and debouncer is
+	// the state machine RunLoop drives with it; see resize.go.
+	resizeDebounce int
+	debouncer      resizeDebouncer
+
+	// videoDecoder produces frames for ElemTypeVideo elements; see
+	// SetVideoFrameDecoder in video.go.
+	videoDecoder VideoFrameDecoder
+
+	// videos holds per-element playback state for ElemTypeVideo elements
+	// keyed by element identity, so two video elements playing the same
+	// file still seek/play independently; see video.go.
+	videos map[*render.RenderElement]*videoState
+
+	// fonts holds custom fonts registered by name via LoadCustomFont, and
+	// fallbackFonts is the ordered chain of registered names consulted
+	// for a glyph missing from a run's own font; see fonts.go.
+	fonts         map[string]rl.Font
+	fallbackFonts []string
+
+	// scaleFactor is cfg.ScaleFactor from Init, used to scale dash/dot/
+	// gap lengths when drawing a BorderStyleDashed/BorderStyleDotted
+	// border; see border.go.
+	scaleFactor float32
+
+	// lastScreenW/H is the window size Draw observed last frame, used to
+	// detect a resize so it can fall back to a full redraw instead of
+	// trusting a damage tracker's rects, which were computed against the
+	// old size; see Draw.
+	lastScreenW, lastScreenH int32
+
+	// damageDebugColor, when non-zero-alpha, is drawn as an outline
+	// around each partial frame's scissored region instead of nothing;
+	// see SetDamageDebugColor.
+	damageDebugColor render.Color
+}
+
+// Renderer satisfies render's core interface plus every optional
+// capability interface it has a full implementation for, so a caller
+// coding against those interfaces can catch a signature drift here at
+// build time instead of at the render.AsXxx call site.
+var (
+	_ render.Renderer        = (*Renderer)(nil)
+	_ render.Runner          = (*Renderer)(nil)
+	_ render.TextureLoader   = (*Renderer)(nil)
+	_ render.FontLoader      = (*Renderer)(nil)
+	_ render.VideoController = (*Renderer)(nil)
+)
+
+// SetPaused freezes or resumes UI activity without tearing down the
+// window, e.g. while a modal native dialog has temporarily taken over
+// input. While paused, PollEvents keeps sampling raylib's input state (so
+// the window doesn't appear to hang) but stops dispatching it to element
+// handlers; Draw is unaffected, since it already only draws the tree's
+// current state rather than re-laying it out.
+func (r *Renderer) SetPaused(paused bool) {
+	r.paused = paused
+}
+
+// Paused reports whether SetPaused(true) is currently in effect.
+func (r *Renderer) Paused() bool {
+	return r.paused
+}
+
+// New creates an unopened raylib Renderer. Call Init to open the window.
+func New() *Renderer {
+	return &Renderer{}
+}
+
+// Init opens the application window per cfg.
+func (r *Renderer) Init(cfg render.WindowConfig) error {
+	title := cfg.Title
+	if title == "" {
+		title = "Kryon"
+	}
+	flags := uint32(0)
+	if cfg.Resizable {
+		flags |= rl.FlagWindowResizable
+	}
+	if cfg.Borderless {
+		flags |= rl.FlagWindowUndecorated
+	}
+	if cfg.AlwaysOnTop {
+		flags |= rl.FlagWindowTopmost
+	}
+	if cfg.VSync {
+		flags |= rl.FlagVsyncHint
+	}
+	rl.SetConfigFlags(flags)
+	rl.InitWindow(int32(cfg.Width), int32(cfg.Height), title)
+	if cfg.MinWidth > 0 || cfg.MinHeight > 0 {
+		rl.SetWindowMinSize(int(cfg.MinWidth), int(cfg.MinHeight))
+	}
+	if cfg.TargetFPS > 0 {
+		rl.SetTargetFPS(int32(cfg.TargetFPS))
+	}
+	r.resizeDebounce = int(cfg.ResizeDebounceMs)
+	r.debouncer = resizeDebouncer{intervalMs: r.resizeDebounce}
+	r.scaleFactor = cfg.ScaleFactor
+	if r.scaleFactor <= 0 {
+		r.scaleFactor = 1
+	}
+	r.windowOpen = true
+	return nil
+}
+
+// SetTargetFPS changes the render loop's target frame rate at runtime,
+// e.g. dropping it while a low-power/idle mode is active (see
+// render.EnableDamageTracking) and restoring it once the UI is
+// interactive again. A value of 0 or less removes the cap entirely,
+// letting RunLoop run as fast as the display allows.
+func (r *Renderer) SetTargetFPS(fps int) {
+	rl.SetTargetFPS(int32(fps))
+}
+
+// Cleanup closes the window raylib opened in Init, releases any textures
+// loaded since, and resets r to the same zero state New returns -- so a
+// subsequent Init starts clean, with no stale texture cache entries,
+// paused flag, or update hook left over from before. Calling Cleanup
+// more than once, or without a prior Init, is a no-op.
+func (r *Renderer) Cleanup() {
+	if !r.windowOpen {
+		return
+	}
+	for _, tex := range r.textures {
+		rl.UnloadTexture(tex)
+	}
+	for _, tex := range r.grayTextures {
+		rl.UnloadTexture(tex)
+	}
+	if r.hasMissingTexture {
+		rl.UnloadTexture(r.missingTexture)
+	}
+	for _, st := range r.videos {
+		if st.hasTex {
+			rl.UnloadTexture(st.tex)
+		}
+	}
+	for _, font := range r.fonts {
+		rl.UnloadFont(font)
+	}
+	rl.CloseWindow()
+	*r = Renderer{}
+}
@@ -0,0 +1,27 @@
+package raylib
+
+import "github.com/waozixyz/kryon/render"
+
+// Reload re-parses the KRB file at path and rebuilds the tree, the same
+// as render.Reload -- carrying scroll offsets and this Renderer's
+// Focused/Hovered state across to the new tree by matching element IDs
+// -- then invalidates the texture cache so any image whose file changed
+// on disk is picked up on the next Draw rather than reusing a stale
+// upload, and invalidates the video cache so no *RenderElement from
+// oldRoots is left as a dangling key holding a GPU texture nothing will
+// ever unload. The window itself is left open and untouched; only the
+// tree and cached textures change, so window size/position/title survive
+// the reload exactly as they were.
+//
+// Reload doesn't swap anything in place: oldRoots is only read to
+// transfer state, and the caller is responsible for passing newRoots to
+// PollEvents/Draw from then on.
+func (r *Renderer) Reload(path string, oldRoots []*render.RenderElement) (newRoots []*render.RenderElement, cfg render.WindowConfig, err error) {
+	newRoots, cfg, err = render.Reload(path, oldRoots, &r.dispatcher)
+	if err != nil {
+		return nil, render.WindowConfig{}, err
+	}
+	r.InvalidateTextureCache()
+	r.invalidateVideoCache()
+	return newRoots, cfg, nil
+}
@@ -0,0 +1,98 @@
+package raylib
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// DefaultRemoteResourceTimeout bounds how long a remote image fetch may
+// take before it's treated as a failure.
+const DefaultRemoteResourceTimeout = 10 * time.Second
+
+// DefaultMaxRemoteResourceBytes caps how much of a remote image response
+// body is read, so a misbehaving or malicious server can't exhaust
+// memory by serving an unbounded stream.
+const DefaultMaxRemoteResourceBytes = 16 << 20 // 16 MiB
+
+// SetAllowRemoteResources opts r into fetching image resources named as
+// http(s) URLs over the network, instead of treating every resource name
+// as a local path. It defaults to false: a KRB file authored by someone
+// else could otherwise make this renderer issue arbitrary outbound
+// requests, so an app must explicitly allow it.
+func (r *Renderer) SetAllowRemoteResources(allow bool) {
+	r.allowRemoteResources = allow
+}
+
+func isRemoteResource(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// loadImage loads src, whether it's a local path or (with
+// SetAllowRemoteResources enabled) an http(s) URL, into a raylib Image.
+// It returns a zero-value Image on any failure, same as a failed
+// rl.LoadImage, so callers already treating a zero Width/Height as
+// "fall back to the placeholder" need no remote-specific handling.
+func (r *Renderer) loadImage(src string) rl.Image {
+	if !isRemoteResource(src) {
+		return rl.LoadImage(src)
+	}
+	if !r.allowRemoteResources {
+		return rl.Image{}
+	}
+	img, err := fetchRemoteImage(src)
+	if err != nil {
+		return rl.Image{}
+	}
+	return img
+}
+
+// fetchRemoteImage downloads src and decodes it into a raylib Image,
+// bounded by DefaultRemoteResourceTimeout and
+// DefaultMaxRemoteResourceBytes.
+func fetchRemoteImage(src string) (rl.Image, error) {
+	client := http.Client{Timeout: DefaultRemoteResourceTimeout}
+	resp, err := client.Get(src)
+	if err != nil {
+		return rl.Image{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return rl.Image{}, fmt.Errorf("raylib: %s: unexpected status %s", src, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, DefaultMaxRemoteResourceBytes+1))
+	if err != nil {
+		return rl.Image{}, err
+	}
+	if len(data) > DefaultMaxRemoteResourceBytes {
+		return rl.Image{}, fmt.Errorf("raylib: %s: exceeds %d byte limit", src, DefaultMaxRemoteResourceBytes)
+	}
+
+	img := rl.LoadImageFromMemory(remoteImageExt(src), data, int32(len(data)))
+	if img.Width == 0 || img.Height == 0 {
+		return rl.Image{}, fmt.Errorf("raylib: %s: failed to decode image", src)
+	}
+	return img, nil
+}
+
+// remoteImageExt picks the file extension (".png", ".jpg", ...)
+// rl.LoadImageFromMemory needs to know which decoder to use, from src's
+// URL path. PNG is the fallback for a URL with no extension, since it's
+// the most common format for KRB's existing local image resources.
+func remoteImageExt(src string) string {
+	u, err := url.Parse(src)
+	if err != nil {
+		return ".png"
+	}
+	if ext := strings.ToLower(path.Ext(u.Path)); ext != "" {
+		return ext
+	}
+	return ".png"
+}
@@ -0,0 +1,56 @@
+package raylib
+
+// resizeDebouncer decides, frame by frame, whether an in-progress window
+// resize should trigger a full relayout right away or wait out
+// intervalMs before the next one -- with one final exact relayout once
+// the size stops changing -- so dragging a large document's window edge
+// doesn't relayout the full tree on every single frame of the drag. now
+// is seconds from an injected clock (RunLoop uses the now var below)
+// rather than an argless time call, so this is unit-testable with a
+// fake clock; intervalMs <= 0 disables debouncing entirely, keeping the
+// pre-existing immediate-relayout behavior as the default.
+type resizeDebouncer struct {
+	intervalMs int
+
+	initialized           bool
+	lastWidth, lastHeight int
+	resizing              bool
+	lastLayoutAt          float64
+}
+
+// shouldLayout reports whether RunLoop should call render.Layout this
+// frame for a window currently width x height, given now.
+func (d *resizeDebouncer) shouldLayout(width, height int, now float64) bool {
+	if !d.initialized {
+		d.initialized = true
+		d.lastWidth, d.lastHeight = width, height
+		d.lastLayoutAt = now
+		return true
+	}
+
+	resized := width != d.lastWidth || height != d.lastHeight
+	d.lastWidth, d.lastHeight = width, height
+
+	if d.intervalMs <= 0 {
+		return true
+	}
+
+	if resized {
+		d.resizing = true
+		if now-d.lastLayoutAt < float64(d.intervalMs)/1000 {
+			return false
+		}
+		d.lastLayoutAt = now
+		return true
+	}
+
+	if d.resizing {
+		// Size just stabilized: one final exact layout, then back to
+		// normal (non-debounced) behavior until the next resize starts.
+		d.resizing = false
+		d.lastLayoutAt = now
+		return true
+	}
+
+	return true
+}
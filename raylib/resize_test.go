@@ -0,0 +1,53 @@
+package raylib
+
+import "testing"
+
+// resizeDebouncer has no dependency on raylib/GL state, unlike the rest
+// of this package, so it's tested directly with a fake clock rather than
+// left untested like the GL-dependent drawing/input code.
+
+func TestResizeDebouncerDisabledByDefault(t *testing.T) {
+	var d resizeDebouncer // intervalMs == 0
+	d.shouldLayout(800, 600, 0)
+	if !d.shouldLayout(801, 600, 0.001) {
+		t.Fatalf("expected every frame to relayout when intervalMs is 0")
+	}
+	if !d.shouldLayout(802, 600, 0.002) {
+		t.Fatalf("expected every frame to relayout when intervalMs is 0")
+	}
+}
+
+func TestResizeDebouncerThrottlesDuringActiveResize(t *testing.T) {
+	d := resizeDebouncer{intervalMs: 50}
+	d.shouldLayout(800, 600, 0) // establishes the baseline
+
+	if d.shouldLayout(810, 600, 0.01) {
+		t.Fatalf("expected the relayout 10ms into a resize to be throttled (interval is 50ms)")
+	}
+	if d.shouldLayout(820, 600, 0.02) {
+		t.Fatalf("expected the relayout 20ms into a resize to still be throttled")
+	}
+	if !d.shouldLayout(830, 600, 0.06) {
+		t.Fatalf("expected a relayout once 50ms have elapsed since the last one")
+	}
+}
+
+func TestResizeDebouncerDoesOneFinalLayoutWhenSizeStabilizes(t *testing.T) {
+	d := resizeDebouncer{intervalMs: 50}
+	d.shouldLayout(800, 600, 0)
+	d.shouldLayout(900, 600, 0.01) // throttled, still resizing
+
+	if !d.shouldLayout(900, 600, 0.02) {
+		t.Fatalf("expected a final exact relayout on the first frame the size stops changing")
+	}
+	if !d.shouldLayout(900, 600, 0.03) {
+		t.Fatalf("expected normal (non-debounced) relayout once resizing has settled")
+	}
+}
+
+func TestResizeDebouncerFirstCallAlwaysLaysOut(t *testing.T) {
+	d := resizeDebouncer{intervalMs: 50}
+	if !d.shouldLayout(800, 600, 100) {
+		t.Fatalf("expected the very first call to always relayout regardless of the clock value")
+	}
+}
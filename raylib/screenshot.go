@@ -0,0 +1,30 @@
+package raylib
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Screenshot captures the contents of the current frame buffer. It must
+// be called after a Draw, before the next BeginDrawing call swaps it.
+func (r *Renderer) Screenshot() (image.Image, error) {
+	if !r.windowOpen {
+		return nil, fmt.Errorf("raylib: window not open")
+	}
+	shot := rl.LoadImageFromScreen()
+	defer rl.UnloadImage(shot)
+
+	img := image.NewRGBA(image.Rect(0, 0, int(shot.Width), int(shot.Height)))
+	colors := rl.LoadImageColors(shot)
+	defer rl.UnloadImageColors(colors)
+	for y := 0; y < int(shot.Height); y++ {
+		for x := 0; x < int(shot.Width); x++ {
+			c := colors[y*int(shot.Width)+x]
+			img.Set(x, y, color.RGBA{R: c.R, G: c.G, B: c.B, A: c.A})
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,90 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// fallbackFontSize matches render.WindowConfig's DefaultFontSize. This
+// backend doesn't yet decode krb.PropIDFontSize onto individual elements
+// (text drawing itself is a separate, still-unimplemented piece), so
+// every measurement uses this one size until that lands.
+const fallbackFontSize = 18
+
+// updateSelection implements press-drag-release text selection for
+// Selectable elements: a fresh press sets the anchor at the nearest
+// character boundary (via render.CharIndexAtX, using this backend's own
+// font metrics), dragging with the button still down extends it,
+// pressing elsewhere clears whatever was previously selected, and Ctrl+C
+// copies the selection to the OS clipboard. hit is whatever PollEvents'
+// InputDispatcher.Dispatch hit-tested this frame.
+func (r *Renderer) updateSelection(hit *render.RenderElement, state render.InputState) {
+	leftDown := state.MouseDown[render.MouseButtonLeft]
+	justPressed := leftDown && !r.prevLeftDown
+	r.prevLeftDown = leftDown
+
+	if justPressed {
+		if r.selecting != nil && r.selecting != hit {
+			render.ClearSelection(r.selecting)
+			r.selecting = nil
+		}
+		if hit != nil && hit.Selectable {
+			idx := r.charIndexAt(hit, state.MouseX)
+			hit.SelectionStart, hit.SelectionEnd = idx, idx
+			r.selecting = hit
+		}
+		return
+	}
+
+	if r.selecting != nil && leftDown {
+		r.selecting.SelectionEnd = r.charIndexAt(r.selecting, state.MouseX)
+	}
+
+	if r.selecting != nil && state.Ctrl && rl.IsKeyPressed(rl.KeyC) {
+		if text := render.SelectedText(r.selecting); text != "" {
+			rl.SetClipboardText(text)
+		}
+	}
+}
+
+// highlightColor is the translucent overlay drawn behind a selected
+// glyph run. Text drawing itself isn't implemented by this backend yet
+// (see fallbackFontSize), so this currently paints over bare background
+// rather than an actual glyph run -- it's still correct geometry for
+// whenever that lands.
+var highlightColor = rl.Color{R: 102, G: 153, B: 255, A: 120}
+
+// drawSelectionHighlight draws a single-line highlight rectangle behind
+// el's current selection, measured the same way charIndexAt resolved it.
+func (r *Renderer) drawSelectionHighlight(el *render.RenderElement) {
+	lo, hi := el.SelectionStart, el.SelectionEnd
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	runes := []rune(el.TextContent)
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > len(runes) {
+		hi = len(runes)
+	}
+	if lo >= hi {
+		return
+	}
+	measure := func(s string) float32 {
+		return rl.MeasureTextEx(rl.GetFontDefault(), s, fallbackFontSize, 0).X
+	}
+	startX := measure(string(runes[:lo]))
+	endX := measure(string(runes[:hi]))
+	rl.DrawRectangle(int32(el.X+startX), int32(el.Y), int32(endX-startX), int32(el.Height), highlightColor)
+}
+
+func (r *Renderer) charIndexAt(el *render.RenderElement, screenX float32) int {
+	localX, _, ok := render.ScreenToElement(el, screenX, el.Y)
+	if !ok {
+		return 0
+	}
+	return render.CharIndexAtX(el.TextContent, localX, func(s string) float32 {
+		return rl.MeasureTextEx(rl.GetFontDefault(), s, fallbackFontSize, 0).X
+	})
+}
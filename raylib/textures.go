@@ -0,0 +1,168 @@
+package raylib
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// DefaultMaxTextureDimension is used when a Renderer's texture dimension
+// cap (see SetMaxTextureDimension) is left at zero, i.e. unset. It's
+// comfortably inside the limit of even fairly old integrated GPUs.
+const DefaultMaxTextureDimension = 4096
+
+// SetMaxTextureDimension caps the width/height of any texture this Renderer
+// uploads to the GPU; images larger than this are downscaled on load.
+// Zero means DefaultMaxTextureDimension. Set a lower value on constrained
+// hardware to avoid driver-level texture size rejections.
+func (r *Renderer) SetMaxTextureDimension(max int32) {
+	r.maxTextureDimension = max
+}
+
+func (r *Renderer) maxTexDim() int32 {
+	if r.maxTextureDimension <= 0 {
+		return DefaultMaxTextureDimension
+	}
+	return r.maxTextureDimension
+}
+
+// loadTexture loads path as a texture, downscaling it first if either
+// dimension exceeds maxTexDim so the upload can't fail or silently clamp
+// on GPUs with a smaller texture size limit. path may be an http(s) URL
+// if SetAllowRemoteResources is enabled; see loadImage. A path raylib
+// can't load (missing file, unsupported format, disallowed/failed remote
+// fetch, ...) never reaches the GPU as a broken texture; it falls back
+// to placeholderTexture so a bad asset shows up as an obviously-wrong
+// magenta square instead of a crash or invisible element.
+func (r *Renderer) loadTexture(path string) rl.Texture2D {
+	img := r.loadImage(path)
+	defer rl.UnloadImage(img)
+	if img.Width == 0 || img.Height == 0 {
+		return r.placeholderTexture()
+	}
+	downscaleToFit(&img, r.maxTexDim())
+	return rl.LoadTextureFromImage(img)
+}
+
+// grayscaleTexture returns a desaturated copy of the texture at path for
+// an element with Grayscale set, loading and caching it in a cache
+// distinct from cachedTexture's so producing it never mutates the
+// original texture -- which is very likely still shared with other,
+// non-grayscale elements drawing the same path.
+func (r *Renderer) grayscaleTexture(path string) rl.Texture2D {
+	if r.grayTextures == nil {
+		r.grayTextures = map[string]rl.Texture2D{}
+	}
+	if tex, ok := r.grayTextures[path]; ok {
+		return tex
+	}
+	img := r.loadImage(path)
+	defer rl.UnloadImage(img)
+	if img.Width == 0 || img.Height == 0 {
+		tex := r.placeholderTexture()
+		r.grayTextures[path] = tex
+		return tex
+	}
+	downscaleToFit(&img, r.maxTexDim())
+	rl.ImageColorGrayscale(&img)
+	tex := rl.LoadTextureFromImage(img)
+	r.grayTextures[path] = tex
+	return tex
+}
+
+// placeholderTexture returns the shared fallback texture drawn in place
+// of an image that failed to load, creating it on first use.
+func (r *Renderer) placeholderTexture() rl.Texture2D {
+	if !r.hasMissingTexture {
+		img := rl.GenImageColor(2, 2, rl.Magenta)
+		r.missingTexture = rl.LoadTextureFromImage(img)
+		rl.UnloadImage(img)
+		r.hasMissingTexture = true
+	}
+	return r.missingTexture
+}
+
+// InvalidateTextureCache unloads every texture cached by cachedTexture
+// and grayscaleTexture and clears both caches, so the next Draw reloads
+// each ImagePath fresh from disk instead of reusing a texture that may
+// no longer match the file on disk. Intended for use after a Reload,
+// where an updated .kry/.krb may reference an image whose file content
+// changed since it was first loaded. The placeholder texture is left
+// alone, since it never reads from disk in the first place.
+func (r *Renderer) InvalidateTextureCache() {
+	for _, tex := range r.textures {
+		rl.UnloadTexture(tex)
+	}
+	r.textures = nil
+	for _, tex := range r.grayTextures {
+		rl.UnloadTexture(tex)
+	}
+	r.grayTextures = nil
+}
+
+// downscaleToFit resizes img in place, preserving aspect ratio, if
+// either dimension exceeds max.
+func downscaleToFit(img *rl.Image, max int32) {
+	if img.Width <= max && img.Height <= max {
+		return
+	}
+	scale := float32(max) / float32(img.Width)
+	if hScale := float32(max) / float32(img.Height); hScale < scale {
+		scale = hScale
+	}
+	newW := int32(float32(img.Width) * scale)
+	newH := int32(float32(img.Height) * scale)
+	rl.ImageResize(img, newW, newH)
+}
+
+// ProgressFunc is render.ProgressFunc, kept as a local alias so existing
+// callers referencing raylib.ProgressFunc don't need to change; see
+// render.TextureLoader for why PreloadTextures' signature needs a
+// render-package type rather than one private to this backend.
+type ProgressFunc = render.ProgressFunc
+
+// PreloadTextures loads and uploads every path in paths into r's texture
+// cache (see cachedTexture in draw.go), so a subsequent Draw over an
+// element referencing one of them doesn't stall on disk I/O. Decoding
+// happens concurrently across paths, since it's pure CPU/IO work; GPU
+// upload is serialized on the calling goroutine, since raylib's OpenGL
+// context is only safe to use from the thread Init was called on.
+// onProgress, if non-nil, is called once per path as it finishes,
+// reporting how many of the total have completed so far. It returns an
+// error, rather than driving raylib's GPU-upload calls with no context
+// behind them, if called before Init or after Cleanup.
+func (r *Renderer) PreloadTextures(paths []string, onProgress ProgressFunc) error {
+	if !r.windowOpen {
+		return fmt.Errorf("raylib: window not open")
+	}
+	type decoded struct {
+		path string
+		img  rl.Image
+	}
+	results := make(chan decoded, len(paths))
+	for _, path := range paths {
+		go func(path string) {
+			img := r.loadImage(path)
+			downscaleToFit(&img, r.maxTexDim())
+			results <- decoded{path: path, img: img}
+		}(path)
+	}
+
+	if r.textures == nil {
+		r.textures = map[string]rl.Texture2D{}
+	}
+	for i := 0; i < len(paths); i++ {
+		d := <-results
+		if d.img.Width == 0 || d.img.Height == 0 {
+			r.textures[d.path] = r.placeholderTexture()
+		} else {
+			r.textures[d.path] = rl.LoadTextureFromImage(d.img)
+		}
+		rl.UnloadImage(d.img)
+		if onProgress != nil {
+			onProgress(i+1, len(paths))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// tooltipPadding insets a tooltip's text from its floating box's edges.
+const tooltipPadding = 6
+
+// tooltipOffsetX/Y place a tooltip's box below and to the right of the
+// cursor, clear of the hot spot it's reporting on.
+const (
+	tooltipOffsetX = 16
+	tooltipOffsetY = 16
+)
+
+var (
+	tooltipBgColor = rl.Color{R: 32, G: 32, B: 32, A: 230}
+	tooltipFgColor = rl.White
+)
+
+// drawTooltip draws a small floating box with text near the cursor, on
+// top of whatever Draw already drew this frame -- the same reasoning as
+// DrawSubtree's popup use case, just inlined into the main Draw call
+// since a tooltip has no subtree of its own to redraw separately.
+func (r *Renderer) drawTooltip(text string) {
+	runs := r.selectFontRuns("", text)
+	var textW float32
+	for _, run := range runs {
+		textW += rl.MeasureTextEx(run.Font, run.Text, fallbackFontSize, 0).X
+	}
+
+	mouse := rl.GetMousePosition()
+	x := mouse.X + tooltipOffsetX
+	y := mouse.Y + tooltipOffsetY
+	w := textW + tooltipPadding*2
+	h := float32(fallbackFontSize) + tooltipPadding*2
+
+	rl.DrawRectangle(int32(x), int32(y), int32(w), int32(h), tooltipBgColor)
+	cursorX := x + tooltipPadding
+	for _, run := range runs {
+		rl.DrawTextEx(run.Font, run.Text, rl.Vector2{X: cursorX, Y: y + tooltipPadding}, fallbackFontSize, 0, tooltipFgColor)
+		cursorX += rl.MeasureTextEx(run.Font, run.Text, fallbackFontSize, 0).X
+	}
+}
+
+// drawTooltipIfHovered draws the current tooltip, if any, from
+// r.dispatcher's hover state. Called last in Draw so it overlays everything else this frame.
+func (r *Renderer) drawTooltipIfHovered() {
+	if _, text, ok := render.TooltipTarget(&r.dispatcher); ok {
+		r.drawTooltip(text)
+	}
+}
@@ -0,0 +1,117 @@
+package raylib
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/waozixyz/kryon/render"
+)
+
+// VideoFrameDecoder produces the frame of the video at path that should
+// be on screen at atSeconds, reporting false if it has none (end of
+// stream, decode failure, ...). raylib itself has no video codec, so
+// playing back any real video format requires the host application to
+// register one of these; without one, video elements fall back to
+// placeholderTexture like a missing image would.
+type VideoFrameDecoder func(path string, atSeconds float32) (rl.Image, bool)
+
+// videoState is the per-element playback state for an ElemTypeVideo
+// element: a playhead plus the texture last uploaded for it.
+type videoState struct {
+	playing  bool
+	position float32
+
+	tex    rl.Texture2D
+	hasTex bool
+}
+
+// SetVideoFrameDecoder registers the callback videoTexture uses to
+// produce frames for ElemTypeVideo elements. A nil decoder (the default)
+// makes every video element draw as placeholderTexture.
+func (r *Renderer) SetVideoFrameDecoder(decode VideoFrameDecoder) {
+	r.videoDecoder = decode
+}
+
+// PlayVideo starts or resumes playback of el's video from its current
+// position. el must be an ElemTypeVideo element.
+func (r *Renderer) PlayVideo(el *render.RenderElement) {
+	r.videoStateFor(el).playing = true
+}
+
+// PauseVideo stops advancing el's playhead without resetting it, so a
+// subsequent PlayVideo resumes from the same position.
+func (r *Renderer) PauseVideo(el *render.RenderElement) {
+	r.videoStateFor(el).playing = false
+}
+
+// SeekVideo moves el's playhead to seconds, whether or not it's
+// currently playing.
+func (r *Renderer) SeekVideo(el *render.RenderElement, seconds float32) {
+	r.videoStateFor(el).position = seconds
+}
+
+// invalidateVideoCache unloads every texture uploaded for a video element
+// and clears r.videos, so a stale entry keyed by an element from before a
+// Reload doesn't sit there leaking its GPU texture forever. Like
+// InvalidateTextureCache, it always drops every entry rather than just
+// the ones missing from the new tree -- Reload always produces a fresh
+// *RenderElement for every element, so every existing key is stale by
+// construction.
+func (r *Renderer) invalidateVideoCache() {
+	for _, st := range r.videos {
+		if st.hasTex {
+			rl.UnloadTexture(st.tex)
+		}
+	}
+	r.videos = nil
+}
+
+// videoStateFor returns el's playback state, creating it on first use.
+func (r *Renderer) videoStateFor(el *render.RenderElement) *videoState {
+	if r.videos == nil {
+		r.videos = map[*render.RenderElement]*videoState{}
+	}
+	st, ok := r.videos[el]
+	if !ok {
+		st = &videoState{}
+		r.videos[el] = st
+	}
+	return st
+}
+
+// advanceVideos steps the playhead of every currently-playing video
+// forward by dt seconds. Called once per Draw, the same way
+// render.AdvanceAnimations steps sprite animations.
+func (r *Renderer) advanceVideos(dt float32) {
+	for _, st := range r.videos {
+		if st.playing {
+			st.position += dt
+		}
+	}
+}
+
+// videoTexture returns the texture to draw for el's current playhead
+// position, decoding a fresh frame through the registered
+// VideoFrameDecoder if any and uploading it in place of whatever el was
+// last showing. No decoder registered, or a decode failure on a video
+// that hasn't shown a frame yet, falls back to placeholderTexture; a
+// decode failure after a frame has already been shown just keeps
+// showing that frame rather than flashing the placeholder.
+func (r *Renderer) videoTexture(el *render.RenderElement) rl.Texture2D {
+	st := r.videoStateFor(el)
+	if r.videoDecoder == nil {
+		return r.placeholderTexture()
+	}
+	frame, ok := r.videoDecoder(el.ImagePath, st.position)
+	if !ok || frame.Width == 0 || frame.Height == 0 {
+		if st.hasTex {
+			return st.tex
+		}
+		return r.placeholderTexture()
+	}
+	if st.hasTex {
+		rl.UnloadTexture(st.tex)
+	}
+	downscaleToFit(&frame, r.maxTexDim())
+	st.tex = rl.LoadTextureFromImage(frame)
+	st.hasTex = true
+	return st.tex
+}
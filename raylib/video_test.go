@@ -0,0 +1,86 @@
+package raylib
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/render"
+)
+
+// PlayVideo/PauseVideo/SeekVideo/advanceVideos only touch the playhead
+// bookkeeping in videoState, not GL state, so they're tested directly
+// like resizeDebouncer (see resize_test.go) rather than left untested with the rest of
+// this package's drawing code.
+
+func TestPlayPauseSeekVideo(t *testing.T) {
+	var r Renderer
+	el := &render.RenderElement{}
+
+	r.PlayVideo(el)
+	r.advanceVideos(0.5)
+	if got := r.videoStateFor(el).position; got != 0.5 {
+		t.Fatalf("position after playing 0.5s = %v, want 0.5", got)
+	}
+
+	r.PauseVideo(el)
+	r.advanceVideos(0.5)
+	if got := r.videoStateFor(el).position; got != 0.5 {
+		t.Fatalf("position advanced while paused: got %v, want 0.5", got)
+	}
+
+	r.SeekVideo(el, 10)
+	if got := r.videoStateFor(el).position; got != 10 {
+		t.Fatalf("position after seek = %v, want 10", got)
+	}
+}
+
+func TestInvalidateVideoCacheDropsEveryEntry(t *testing.T) {
+	var r Renderer
+	old1 := &render.RenderElement{}
+	old2 := &render.RenderElement{}
+	r.videoStateFor(old1).playing = true
+	r.videoStateFor(old2).position = 5
+
+	r.invalidateVideoCache()
+
+	if len(r.videos) != 0 {
+		t.Fatalf("videos = %v, want empty after invalidateVideoCache", r.videos)
+	}
+	if got := r.videoStateFor(old1).position; got != 0 {
+		t.Fatalf("videoStateFor(old1) after invalidate = %v, want a fresh zero-value state", got)
+	}
+}
+
+func TestReloadInvalidatesVideoCache(t *testing.T) {
+	var r Renderer
+	oldRoots, _, err := render.Reload("../examples/hello_world.krb", nil, nil)
+	if err != nil {
+		t.Fatalf("Reload (initial load): %v", err)
+	}
+	r.PlayVideo(oldRoots[0])
+
+	if _, _, err := r.Reload("../examples/hello_world.krb", oldRoots); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if len(r.videos) != 0 {
+		t.Fatalf("videos = %v, want empty after Reload -- the old tree's element is still keyed in the cache, leaking its GPU texture", r.videos)
+	}
+}
+
+func TestAdvanceVideosOnlyAffectsPlayingElements(t *testing.T) {
+	var r Renderer
+	playing := &render.RenderElement{}
+	paused := &render.RenderElement{}
+
+	r.PlayVideo(playing)
+	r.videoStateFor(paused) // registered but never played
+
+	r.advanceVideos(1)
+
+	if got := r.videoStateFor(playing).position; got != 1 {
+		t.Fatalf("playing element position = %v, want 1", got)
+	}
+	if got := r.videoStateFor(paused).position; got != 0 {
+		t.Fatalf("non-playing element position = %v, want 0", got)
+	}
+}
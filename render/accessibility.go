@@ -0,0 +1,93 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// AccessibilityRole is a coarse, screen-reader-friendly classification of
+// an element, independent of its KRB element type.
+type AccessibilityRole string
+
+const (
+	RoleWindow    AccessibilityRole = "window"
+	RoleContainer AccessibilityRole = "container"
+	RoleText      AccessibilityRole = "text"
+	RoleImage     AccessibilityRole = "image"
+	RoleButton    AccessibilityRole = "button"
+	RoleInput     AccessibilityRole = "input"
+	RoleList      AccessibilityRole = "list"
+	RoleUnknown   AccessibilityRole = "unknown"
+)
+
+// AccessibilityNode is a serializable snapshot of one RenderElement,
+// suitable for dumping to JSON for screen readers or UI test automation.
+type AccessibilityNode struct {
+	ID       string               `json:"id,omitempty"`
+	Role     AccessibilityRole    `json:"role"`
+	Label    string               `json:"label,omitempty"`
+	X        float32              `json:"x"`
+	Y        float32              `json:"y"`
+	Width    float32              `json:"width"`
+	Height   float32              `json:"height"`
+	Children []*AccessibilityNode `json:"children,omitempty"`
+}
+
+// ExportAccessibilityTree walks the given render trees and produces a
+// plain-data snapshot that doesn't hold references back into the live
+// RenderElement tree, so it's safe to serialize or hand to another
+// goroutine/process (e.g. a test harness driving the UI by label).
+func ExportAccessibilityTree(roots []*RenderElement) []*AccessibilityNode {
+	nodes := make([]*AccessibilityNode, 0, len(roots))
+	for _, r := range roots {
+		nodes = append(nodes, exportNode(r))
+	}
+	return nodes
+}
+
+func exportNode(el *RenderElement) *AccessibilityNode {
+	n := &AccessibilityNode{
+		ID:     el.ID,
+		Role:   accessibilityRole(el.Type),
+		Label:  el.TextContent,
+		X:      el.X,
+		Y:      el.Y,
+		Width:  el.Width,
+		Height: el.Height,
+	}
+	for _, c := range el.Children {
+		n.Children = append(n.Children, exportNode(c))
+	}
+	return n
+}
+
+func accessibilityRole(elemType uint8) AccessibilityRole {
+	switch elemType {
+	case krb.ElemTypeApp:
+		return RoleWindow
+	case krb.ElemTypeContainer, krb.ElemTypeCanvas:
+		return RoleContainer
+	case krb.ElemTypeText:
+		return RoleText
+	case krb.ElemTypeImage:
+		return RoleImage
+	case krb.ElemTypeButton:
+		return RoleButton
+	case krb.ElemTypeInput:
+		return RoleInput
+	case krb.ElemTypeList, krb.ElemTypeGrid, krb.ElemTypeScrollable:
+		return RoleList
+	default:
+		return RoleUnknown
+	}
+}
+
+// WriteAccessibilityJSON exports roots and writes them to w as indented
+// JSON, e.g. for a test harness to diff against a golden file.
+func WriteAccessibilityJSON(w io.Writer, roots []*RenderElement) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ExportAccessibilityTree(roots))
+}
@@ -0,0 +1,28 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestExportAccessibilityTreeIncludesLabelsAndRoles(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	btn := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeButton,
+		Properties: []krb.Property{{ID: krb.PropIDTextContent, Type: krb.ValTypeString, Value: []byte{0}}}})
+	btn.TextContent = "Submit"
+
+	nodes := ExportAccessibilityTree([]*RenderElement{btn})
+	if len(nodes) != 1 || nodes[0].Role != RoleButton || nodes[0].Label != "Submit" {
+		t.Fatalf("unexpected accessibility node: %+v", nodes[0])
+	}
+
+	var buf strings.Builder
+	if err := WriteAccessibilityJSON(&buf, []*RenderElement{btn}); err != nil {
+		t.Fatalf("WriteAccessibilityJSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"role": "button"`) {
+		t.Fatalf("expected JSON output to contain the button role, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,49 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// Anchor values for the conventional "anchor" custom property.
+const (
+	AnchorTopLeft     = "top-left"
+	AnchorTopRight    = "top-right"
+	AnchorBottomLeft  = "bottom-left"
+	AnchorBottomRight = "bottom-right"
+	AnchorCenter      = "center"
+)
+
+// applyAnchorCustomProperties reads the conventional "anchor" custom
+// property, plus optional "offsetX"/"offsetY", used by an absolutely
+// positioned element (krb.LayoutAbsoluteBit) to pin itself to a parent
+// corner or center instead of always measuring from the top-left. This
+// covers the common overlay cases (badges, FABs, toasts) declaratively,
+// in place of a one-off handler like TabBar's. See layoutFlowChildren
+// for where Anchor/OffsetX/OffsetY are resolved into X/Y.
+func applyAnchorCustomProperties(re *RenderElement) {
+	re.Anchor = ""
+	re.OffsetX = 0
+	re.OffsetY = 0
+	for _, cp := range re.Source.CustomProperties {
+		switch re.Doc.String(cp.KeyIndex) {
+		case "anchor":
+			re.Anchor = customStringValue(re.Doc, cp)
+		case "offsetX":
+			re.OffsetX = customNumberValue(cp)
+		case "offsetY":
+			re.OffsetY = customNumberValue(cp)
+		}
+	}
+}
+
+// customNumberValue decodes a custom property's Value the same way
+// Gap/Min/MaxWidth etc. decode a standard property's pixel amount: a
+// little-endian uint16 when two bytes are present, or a single byte.
+func customNumberValue(cp krb.CustomProperty) float32 {
+	switch len(cp.Value) {
+	case 0:
+		return 0
+	case 1:
+		return float32(cp.Value[0])
+	default:
+		return float32(uint16(cp.Value[0]) | uint16(cp.Value[1])<<8)
+	}
+}
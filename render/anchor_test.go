@@ -0,0 +1,78 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func newAnchoredChild(doc *krb.Document, anchor string, offsetX, offsetY byte) *RenderElement {
+	c := newRenderElement(doc, &krb.Element{
+		Type:   krb.ElemTypeContainer,
+		Layout: krb.LayoutAbsoluteBit,
+	})
+	c.Width, c.Height = 20, 10
+	c.Anchor = anchor
+	c.OffsetX = float32(offsetX)
+	c.OffsetY = float32(offsetY)
+	return c
+}
+
+func anchoredParent(doc *krb.Document) *RenderElement {
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	parent.Width, parent.Height = 200, 100
+	parent.PaddingDim = [4]Dimension{{Value: 5}, {Value: 5}, {Value: 5}, {Value: 5}}
+	parent.Padding = resolvePaddingDims(parent.PaddingDim, parent.Width)
+	return parent
+}
+
+func TestAnchorPositionsEachCornerAndCenter(t *testing.T) {
+	doc := &krb.Document{}
+	cases := []struct {
+		anchor string
+		wantX  float32
+		wantY  float32
+	}{
+		{AnchorTopLeft, 5 + 2, 5 + 3},
+		{AnchorTopRight, 200 - 5 - 20 - 2, 5 + 3},
+		{AnchorBottomLeft, 5 + 2, 100 - 5 - 10 - 3},
+		{AnchorBottomRight, 200 - 5 - 20 - 2, 100 - 5 - 10 - 3},
+		{AnchorCenter, 5 + (200-10-20)/2 + 2, 5 + (100-10-10)/2 + 3},
+	}
+	for _, tc := range cases {
+		parent := anchoredParent(doc)
+		child := newAnchoredChild(doc, tc.anchor, 2, 3)
+		parent.Children = []*RenderElement{child}
+		child.Parent = parent
+
+		var errs []error
+		layoutFlowChildren(parent, &errs)
+
+		if child.X != tc.wantX || child.Y != tc.wantY {
+			t.Fatalf("%s: got (%v, %v), want (%v, %v)", tc.anchor, child.X, child.Y, tc.wantX, tc.wantY)
+		}
+	}
+}
+
+func TestAnchorStaysGluedToCornerAfterParentResize(t *testing.T) {
+	doc := &krb.Document{}
+	parent := anchoredParent(doc)
+	child := newAnchoredChild(doc, AnchorBottomRight, 4, 6)
+	parent.Children = []*RenderElement{child}
+	child.Parent = parent
+
+	var errs []error
+	layoutFlowChildren(parent, &errs)
+	if child.X != 200-5-20-4 || child.Y != 100-5-10-6 {
+		t.Fatalf("initial position wrong: got (%v, %v)", child.X, child.Y)
+	}
+
+	parent.Width, parent.Height = 400, 300
+	layoutFlowChildren(parent, &errs)
+	if want := float32(400 - 5 - 20 - 4); child.X != want {
+		t.Fatalf("after resize X = %v, want %v (still glued to right edge)", child.X, want)
+	}
+	if want := float32(300 - 5 - 10 - 6); child.Y != want {
+		t.Fatalf("after resize Y = %v, want %v (still glued to bottom edge)", child.Y, want)
+	}
+}
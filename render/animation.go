@@ -0,0 +1,41 @@
+package render
+
+// AdvanceAnimations steps every sprite-sheet animated image in roots
+// (see RenderElement.FrameCount) forward by dt seconds. A backend calls
+// this once per drawn frame, the same way it calls Layout, before
+// resolving draw rects -- see SpriteFrameRect.
+func AdvanceAnimations(roots []*RenderElement, dt float32) {
+	for _, root := range roots {
+		advanceAnimation(root, dt)
+	}
+}
+
+func advanceAnimation(el *RenderElement, dt float32) {
+	if el.FrameCount > 1 && el.FPS > 0 {
+		frameDuration := 1 / el.FPS
+		el.frameElapsed += dt
+		for el.frameElapsed >= frameDuration {
+			el.frameElapsed -= frameDuration
+			el.CurrentFrame = (el.CurrentFrame + 1) % el.FrameCount
+		}
+	}
+	for _, c := range el.Children {
+		advanceAnimation(c, dt)
+	}
+}
+
+// SpriteFrameRect returns the source rect, in sheetW x sheetH pixel
+// coordinates, of el's current animation frame within its sprite sheet.
+// If el has no animation (FrameCount == 0), it returns the full sheet
+// rect -- the correct source rect for a plain, unanimated image.
+func SpriteFrameRect(el *RenderElement, sheetW, sheetH float32) Rect {
+	if el.FrameCount <= 0 || el.SpriteCols <= 0 || el.SpriteRows <= 0 {
+		return Rect{Width: sheetW, Height: sheetH}
+	}
+	cellW := sheetW / float32(el.SpriteCols)
+	cellH := sheetH / float32(el.SpriteRows)
+	frame := el.CurrentFrame % el.FrameCount
+	col := frame % el.SpriteCols
+	row := frame / el.SpriteCols
+	return Rect{X: float32(col) * cellW, Y: float32(row) * cellH, Width: cellW, Height: cellH}
+}
@@ -0,0 +1,45 @@
+package render
+
+import "testing"
+
+func TestAdvanceAnimationsStepsFrameAtConfiguredFPS(t *testing.T) {
+	el := &RenderElement{FrameCount: 4, FPS: 10} // 0.1s per frame
+	AdvanceAnimations([]*RenderElement{el}, 0.05)
+	if el.CurrentFrame != 0 {
+		t.Fatalf("CurrentFrame = %d after half a frame's worth of time, want 0", el.CurrentFrame)
+	}
+	AdvanceAnimations([]*RenderElement{el}, 0.05)
+	if el.CurrentFrame != 1 {
+		t.Fatalf("CurrentFrame = %d after a full frame's worth of time, want 1", el.CurrentFrame)
+	}
+	AdvanceAnimations([]*RenderElement{el}, 0.35) // 3 more frames, wrapping past FrameCount
+	if el.CurrentFrame != 0 {
+		t.Fatalf("CurrentFrame = %d, want to have wrapped back to 0", el.CurrentFrame)
+	}
+}
+
+func TestAdvanceAnimationsIgnoresStaticImages(t *testing.T) {
+	el := &RenderElement{}
+	AdvanceAnimations([]*RenderElement{el}, 10)
+	if el.CurrentFrame != 0 {
+		t.Fatalf("CurrentFrame = %d for a non-animated element, want 0", el.CurrentFrame)
+	}
+}
+
+func TestSpriteFrameRectLaysOutFramesRowMajor(t *testing.T) {
+	el := &RenderElement{FrameCount: 6, SpriteCols: 3, SpriteRows: 2}
+	el.CurrentFrame = 4 // row 1, col 1
+	got := SpriteFrameRect(el, 300, 200)
+	want := Rect{X: 100, Y: 100, Width: 100, Height: 100}
+	if got != want {
+		t.Fatalf("SpriteFrameRect = %+v, want %+v", got, want)
+	}
+}
+
+func TestSpriteFrameRectIsFullSheetWhenNotAnimated(t *testing.T) {
+	el := &RenderElement{}
+	got := SpriteFrameRect(el, 64, 32)
+	if got != (Rect{Width: 64, Height: 32}) {
+		t.Fatalf("SpriteFrameRect = %+v, want the full sheet rect", got)
+	}
+}
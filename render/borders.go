@@ -0,0 +1,114 @@
+package render
+
+// BorderStyle controls how BorderSegments strokes an element's border,
+// decoded from the PropIDBorderStyle standard property.
+type BorderStyle uint8
+
+const (
+	// BorderStyleSolid draws each side as one unbroken strip. It's the
+	// zero value, reproducing the only behavior this package had before
+	// BorderStyle existed.
+	BorderStyleSolid BorderStyle = iota
+	// BorderStyleDashed draws each side as alternating filled strips and
+	// gaps.
+	BorderStyleDashed
+	// BorderStyleDotted draws each side as short, evenly spaced strokes.
+	BorderStyleDotted
+)
+
+// dashLength and gapLength are BorderStyleDashed's stroke/gap size in
+// unscaled pixels; BorderStyleDotted reuses gapLength between dots and
+// sizes each dot from the side's own thickness instead.
+const (
+	dashLength = 6
+	gapLength  = 4
+)
+
+// BorderSegment is one unbroken piece of border stroke a backend should
+// draw: a filled rect for BorderStyleSolid/BorderStyleDashed, or a short
+// stroke along the segment's long axis for BorderStyleDotted (see
+// raylib.Renderer's border drawing, which draws the former with
+// DrawRectangle and the latter with DrawLineEx).
+type BorderSegment struct {
+	X, Y, Width, Height float32
+	Color               Color
+}
+
+// BorderSegments computes the segments a backend should draw for el's
+// four border sides (top, right, bottom, left; same order as
+// BorderWidths/BorderColors), scaling BorderStyleDashed/BorderStyleDotted
+// dash and gap lengths by scale (a backend's configured
+// WindowConfig.ScaleFactor, or 1 if scale <= 0). A side with zero width
+// or a fully transparent color produces no segments.
+func BorderSegments(el *RenderElement, scale float32) []BorderSegment {
+	if scale <= 0 {
+		scale = 1
+	}
+	var segs []BorderSegment
+	segs = append(segs, borderSideSegments(el.X, el.Y, el.Width, el.BorderWidths[0], true, el.BorderStyle, el.BorderColors[0], scale)...)
+	segs = append(segs, borderSideSegments(el.X+el.Width-el.BorderWidths[1], el.Y, el.Height, el.BorderWidths[1], false, el.BorderStyle, el.BorderColors[1], scale)...)
+	segs = append(segs, borderSideSegments(el.X, el.Y+el.Height-el.BorderWidths[2], el.Width, el.BorderWidths[2], true, el.BorderStyle, el.BorderColors[2], scale)...)
+	segs = append(segs, borderSideSegments(el.X, el.Y, el.Height, el.BorderWidths[3], false, el.BorderStyle, el.BorderColors[3], scale)...)
+	return segs
+}
+
+// borderSideSegments computes one side's segments. (x, y) and length
+// describe the side's strip along its own axis (horizontal for top/
+// bottom, vertical for left/right); thickness is the strip's size on the
+// other axis.
+func borderSideSegments(x, y, length, thickness float32, horizontal bool, style BorderStyle, color Color, scale float32) []BorderSegment {
+	if thickness <= 0 || color.A == 0 || length <= 0 {
+		return nil
+	}
+	mk := func(pos, size float32) BorderSegment {
+		if horizontal {
+			return BorderSegment{X: x + pos, Y: y, Width: size, Height: thickness, Color: color}
+		}
+		return BorderSegment{X: x, Y: y + pos, Width: thickness, Height: size, Color: color}
+	}
+	switch style {
+	case BorderStyleDashed:
+		return dashSegments(length, dashLength*scale, gapLength*scale, mk)
+	case BorderStyleDotted:
+		dot := thickness
+		if dot <= 0 {
+			dot = scale
+		}
+		return dashSegments(length, dot, gapLength*scale, mk)
+	default:
+		return []BorderSegment{mk(0, length)}
+	}
+}
+
+// resolveDefaultBorderWidths fills in a 1px width for any side that has
+// a color but no explicit width of its own -- declaring just a color
+// (whether via PropIDBorderColor for all four sides, or a single
+// PropIDBorderColorTop/Right/Bottom/Left override) should still draw
+// something, rather than silently producing an invisible zero-width
+// border.
+func resolveDefaultBorderWidths(re *RenderElement) {
+	for i, c := range re.BorderColors {
+		if c.A > 0 && re.BorderWidths[i] == 0 {
+			re.BorderWidths[i] = 1
+		}
+	}
+}
+
+// dashSegments lays dash-sized strokes end to end along [0, length),
+// separated by gap, via mk(pos, size) -- which builds the actual
+// rectangle for a run starting at pos with the given size, clipped to
+// length at the far end so the last dash never overshoots the side.
+func dashSegments(length, dash, gap float32, mk func(pos, size float32) BorderSegment) []BorderSegment {
+	if dash <= 0 {
+		return nil
+	}
+	var segs []BorderSegment
+	for pos := float32(0); pos < length; pos += dash + gap {
+		size := dash
+		if pos+size > length {
+			size = length - pos
+		}
+		segs = append(segs, mk(pos, size))
+	}
+	return segs
+}
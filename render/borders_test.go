@@ -0,0 +1,83 @@
+package render
+
+import "testing"
+
+func borderTestElement() *RenderElement {
+	return &RenderElement{
+		Width: 100, Height: 50,
+		BorderWidths: [4]float32{2, 2, 2, 2},
+		BorderColors: [4]Color{
+			{R: 255, A: 255}, {R: 255, A: 255}, {R: 255, A: 255}, {R: 255, A: 255},
+		},
+	}
+}
+
+func TestBorderSegmentsSolidIsOneSegmentPerSide(t *testing.T) {
+	el := borderTestElement()
+	segs := BorderSegments(el, 1)
+	if len(segs) != 4 {
+		t.Fatalf("len(segs) = %d, want 4 (one per side)", len(segs))
+	}
+	top := segs[0]
+	if top != (BorderSegment{X: 0, Y: 0, Width: 100, Height: 2, Color: el.BorderColors[0]}) {
+		t.Fatalf("top segment = %+v", top)
+	}
+}
+
+func TestBorderSegmentsSkipsTransparentOrZeroWidthSides(t *testing.T) {
+	el := borderTestElement()
+	el.BorderColors[1] = Color{} // right side unset
+	el.BorderWidths[2] = 0       // bottom side explicitly zero width
+	segs := BorderSegments(el, 1)
+	if len(segs) != 2 {
+		t.Fatalf("len(segs) = %d, want 2 (top and left only)", len(segs))
+	}
+}
+
+func TestBorderSegmentsDashedProducesMultipleSegments(t *testing.T) {
+	el := borderTestElement()
+	el.BorderStyle = BorderStyleDashed
+	el.Width = 100
+	el.BorderWidths = [4]float32{2, 0, 0, 0}
+	el.BorderColors = [4]Color{{R: 255, A: 255}, {}, {}, {}}
+	segs := BorderSegments(el, 1)
+	if len(segs) < 2 {
+		t.Fatalf("expected a dashed top side to produce multiple segments, got %d", len(segs))
+	}
+	for _, s := range segs {
+		if s.Width > dashLength {
+			t.Fatalf("dash segment width %v exceeds dashLength %v", s.Width, dashLength)
+		}
+	}
+}
+
+func TestBorderSegmentsDashLengthsScale(t *testing.T) {
+	el := borderTestElement()
+	el.BorderStyle = BorderStyleDashed
+	el.BorderWidths = [4]float32{2, 0, 0, 0}
+	el.BorderColors = [4]Color{{R: 255, A: 255}, {}, {}, {}}
+	segs := BorderSegments(el, 2)
+	if segs[0].Width != dashLength*2 {
+		t.Fatalf("scaled dash width = %v, want %v", segs[0].Width, dashLength*2)
+	}
+}
+
+func TestResolveDefaultBorderWidthsAppliesOnePixelWhenOnlyColorSet(t *testing.T) {
+	el := &RenderElement{BorderColors: [4]Color{{}, {R: 255, A: 255}, {}, {}}}
+	resolveDefaultBorderWidths(el)
+	want := [4]float32{0, 1, 0, 0}
+	if el.BorderWidths != want {
+		t.Fatalf("BorderWidths = %v, want %v", el.BorderWidths, want)
+	}
+}
+
+func TestResolveDefaultBorderWidthsLeavesExplicitWidthAlone(t *testing.T) {
+	el := &RenderElement{
+		BorderColors: [4]Color{{}, {R: 255, A: 255}, {}, {}},
+		BorderWidths: [4]float32{0, 5, 0, 0},
+	}
+	resolveDefaultBorderWidths(el)
+	if el.BorderWidths[1] != 5 {
+		t.Fatalf("BorderWidths[1] = %v, want explicit 5 preserved", el.BorderWidths[1])
+	}
+}
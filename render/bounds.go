@@ -0,0 +1,108 @@
+package render
+
+// Rect is an axis-aligned rectangle in screen coordinates, the
+// backend-agnostic return type for ElementBounds and ContentBounds. A
+// backend wanting its own rectangle type (e.g. raylib's rl.Rectangle)
+// converts from this one rather than this package depending on it.
+type Rect struct {
+	X, Y, Width, Height float32
+}
+
+// ElementBounds returns el's post-layout screen rect, or ok=false if el
+// hasn't been laid out yet or isn't currently EffectiveVisible. It
+// routes through resolveScreenPosition rather than reading el.X/el.Y
+// directly, so that scroll offsets and transforms, once they exist, only
+// need to change in one place to be picked up here, by ContentBounds,
+// and by ScreenToElement/ElementToScreen.
+func ElementBounds(el *RenderElement) (Rect, bool) {
+	if !el.laidOut || !el.EffectiveVisible {
+		return Rect{}, false
+	}
+	x, y := resolveScreenPosition(el)
+	return Rect{X: x, Y: y, Width: el.Width, Height: el.Height}, true
+}
+
+// resolveScreenPosition is the single place a screen-space position is
+// derived for el. Today that's just its own X/Y; a future scroll-offset
+// or transform feature belongs here, not duplicated into every caller
+// that currently reads el.X/el.Y.
+func resolveScreenPosition(el *RenderElement) (float32, float32) {
+	return el.X, el.Y
+}
+
+// ContentBounds returns el's content rect -- its bounds inset by
+// Padding -- the same box layoutFlowChildren arranges el's own children
+// within (see contentRectFrom).
+func ContentBounds(el *RenderElement) (Rect, bool) {
+	bounds, ok := ElementBounds(el)
+	if !ok {
+		return Rect{}, false
+	}
+	return contentRectFrom(bounds, el.Padding), true
+}
+
+// contentRectFrom insets bounds by padding ([0]top, [1]right, [2]bottom,
+// [3]left). It's shared by ContentBounds and layoutFlowChildren so the
+// content-box arithmetic can't drift between the two.
+func contentRectFrom(bounds Rect, padding [4]float32) Rect {
+	return Rect{
+		X:      bounds.X + padding[3],
+		Y:      bounds.Y + padding[0],
+		Width:  bounds.Width - padding[1] - padding[3],
+		Height: bounds.Height - padding[0] - padding[2],
+	}
+}
+
+// DocumentBounds returns the union of every root's ElementBounds: the
+// full on-screen extent of a laid-out document, for a backend wanting to
+// fit its window to content, report scroll extents, or crop a screenshot
+// export to just the rendered UI. ok is false if roots is empty or none
+// of them currently have valid bounds (not yet laid out, or not
+// EffectiveVisible).
+func DocumentBounds(roots []*RenderElement) (Rect, bool) {
+	var union Rect
+	found := false
+	for _, root := range roots {
+		b, ok := ElementBounds(root)
+		if !ok {
+			continue
+		}
+		if !found {
+			union = b
+			found = true
+			continue
+		}
+		union = unionRect(union, b)
+	}
+	return union, found
+}
+
+// unionRect returns the smallest rect containing both a and b.
+func unionRect(a, b Rect) Rect {
+	minX := minFloat32(a.X, b.X)
+	minY := minFloat32(a.Y, b.Y)
+	maxX := maxFloat32(a.X+a.Width, b.X+b.Width)
+	maxY := maxFloat32(a.Y+a.Height, b.Y+b.Height)
+	return Rect{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// ScreenToElement converts a point in screen coordinates into el's local
+// coordinate space, relative to its own top-left corner. ok is false
+// under the same conditions as ElementBounds.
+func ScreenToElement(el *RenderElement, x, y float32) (localX, localY float32, ok bool) {
+	bounds, ok := ElementBounds(el)
+	if !ok {
+		return 0, 0, false
+	}
+	return x - bounds.X, y - bounds.Y, true
+}
+
+// ElementToScreen converts a point in el's local coordinate space into
+// screen coordinates, the inverse of ScreenToElement.
+func ElementToScreen(el *RenderElement, x, y float32) (screenX, screenY float32, ok bool) {
+	bounds, ok := ElementBounds(el)
+	if !ok {
+		return 0, 0, false
+	}
+	return bounds.X + x, bounds.Y + y, true
+}
@@ -0,0 +1,132 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestElementBoundsFalseBeforeLayout(t *testing.T) {
+	doc := &krb.Document{}
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	if _, ok := ElementBounds(el); ok {
+		t.Fatalf("expected ElementBounds to report false before any Layout call")
+	}
+}
+
+func TestElementBoundsFalseWhenNotEffectiveVisible(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 100, 100
+	Layout([]*RenderElement{root}, 100, 100)
+
+	root.IsVisible = false
+	Layout([]*RenderElement{root}, 100, 100)
+	if _, ok := ElementBounds(root); ok {
+		t.Fatalf("expected ElementBounds to report false for a hidden element")
+	}
+}
+
+func TestContentBoundsInsetsByPaddingForNestedContainers(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	outerSrc := &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDPadding, Type: krb.ValTypeEdgeInsets, Value: []byte{10, 10, 10, 10}}},
+	}
+	innerSrc := &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDPadding, Type: krb.ValTypeEdgeInsets, Value: []byte{5, 5, 5, 5}}},
+	}
+	outer := newRenderElement(doc, outerSrc)
+	inner := newRenderElement(doc, innerSrc)
+	inner.Parent = outer
+	outer.Children = []*RenderElement{inner}
+	outer.Width, outer.Height = 200, 150
+
+	Layout([]*RenderElement{outer}, 200, 150)
+
+	outerContent, ok := ContentBounds(outer)
+	if !ok {
+		t.Fatalf("ContentBounds(outer) reported not ok")
+	}
+	wantOuter := Rect{X: 10, Y: 10, Width: 180, Height: 130}
+	if outerContent != wantOuter {
+		t.Fatalf("ContentBounds(outer) = %+v, want %+v", outerContent, wantOuter)
+	}
+
+	innerBounds, ok := ElementBounds(inner)
+	if !ok {
+		t.Fatalf("ElementBounds(inner) reported not ok")
+	}
+	if innerBounds.X != wantOuter.X || innerBounds.Y != wantOuter.Y {
+		t.Fatalf("ElementBounds(inner) = %+v, want positioned at outer's content origin %+v", innerBounds, wantOuter)
+	}
+
+	innerContent, ok := ContentBounds(inner)
+	if !ok {
+		t.Fatalf("ContentBounds(inner) reported not ok")
+	}
+	wantInner := Rect{X: innerBounds.X + 5, Y: innerBounds.Y + 5, Width: innerBounds.Width - 10, Height: innerBounds.Height - 10}
+	if innerContent != wantInner {
+		t.Fatalf("ContentBounds(inner) = %+v, want %+v", innerContent, wantInner)
+	}
+}
+
+func TestDocumentBoundsUnionsMultipleRoots(t *testing.T) {
+	doc := &krb.Document{}
+	a := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	a.Width, a.Height = 50, 50
+	b := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	b.Width, b.Height = 50, 50
+
+	Layout([]*RenderElement{a, b}, 50, 50)
+	a.X, a.Y = -10, -10
+	b.X, b.Y = 40, 40
+	Layout([]*RenderElement{a, b}, 50, 50)
+
+	got, ok := DocumentBounds([]*RenderElement{a, b})
+	if !ok {
+		t.Fatalf("DocumentBounds reported not ok")
+	}
+	want := Rect{X: -10, Y: -10, Width: 100, Height: 100}
+	if got != want {
+		t.Fatalf("DocumentBounds = %+v, want %+v", got, want)
+	}
+}
+
+func TestDocumentBoundsFalseWhenEmptyOrAllInvalid(t *testing.T) {
+	if _, ok := DocumentBounds(nil); ok {
+		t.Fatalf("expected DocumentBounds(nil) to report false")
+	}
+
+	doc := &krb.Document{}
+	notLaidOut := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	if _, ok := DocumentBounds([]*RenderElement{notLaidOut}); ok {
+		t.Fatalf("expected DocumentBounds to report false when no root has valid bounds")
+	}
+}
+
+func TestScreenToElementAndElementToScreenRoundTrip(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 100, 100
+	Layout([]*RenderElement{root}, 100, 100)
+	root.X, root.Y = 20, 30
+	Layout([]*RenderElement{root}, 100, 100)
+
+	localX, localY, ok := ScreenToElement(root, 25, 45)
+	if !ok {
+		t.Fatalf("ScreenToElement reported not ok")
+	}
+	if localX != 5 || localY != 15 {
+		t.Fatalf("ScreenToElement(25,45) = (%v,%v), want (5,15)", localX, localY)
+	}
+
+	screenX, screenY, ok := ElementToScreen(root, localX, localY)
+	if !ok {
+		t.Fatalf("ElementToScreen reported not ok")
+	}
+	if screenX != 25 || screenY != 45 {
+		t.Fatalf("ElementToScreen round-trip = (%v,%v), want (25,45)", screenX, screenY)
+	}
+}
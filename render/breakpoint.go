@@ -0,0 +1,173 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// BreakpointDef is one named width threshold declared via the App
+// element's "breakpoints" custom property, e.g. "narrow<600,wide>=600"
+// declares BreakpointDef{Name: "narrow", AtLeast: false, Width: 600} and
+// BreakpointDef{Name: "wide", AtLeast: true, Width: 600}. A
+// "layout@narrow" or "visible@wide" custom property elsewhere in the
+// document then names one of these to make its override conditional on
+// it; see applyBreakpointCustomProperties and resolveBreakpoints.
+type BreakpointDef struct {
+	Name    string
+	AtLeast bool // true for ">=width", false for "<width"
+	Width   float32
+}
+
+// Matches reports whether viewportWidth satisfies b's threshold.
+func (b BreakpointDef) Matches(viewportWidth float32) bool {
+	if b.AtLeast {
+		return viewportWidth >= b.Width
+	}
+	return viewportWidth < b.Width
+}
+
+// parseBreakpoints parses the "breakpoints" custom property's
+// comma-separated "name<width" / "name>=width" declarations. An
+// unparseable entry is skipped rather than failing the whole list, the
+// same tolerance windowConfigFromApp gives a malformed standard property.
+func parseBreakpoints(spec string) []BreakpointDef {
+	var defs []BreakpointDef
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		opIdx := strings.IndexAny(part, "<>")
+		if opIdx <= 0 {
+			continue
+		}
+		name, rest := part[:opIdx], part[opIdx:]
+		atLeast := strings.HasPrefix(rest, ">=")
+		switch {
+		case atLeast:
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "<"):
+			rest = rest[1:]
+		default:
+			continue
+		}
+		width, err := strconv.ParseFloat(strings.TrimSpace(rest), 32)
+		if err != nil {
+			continue
+		}
+		defs = append(defs, BreakpointDef{Name: name, AtLeast: atLeast, Width: float32(width)})
+	}
+	return defs
+}
+
+// breakpointOverride is one "<property>@<breakpoint>" custom property
+// declaration, precomputed once per style application (see
+// applyBreakpointCustomProperties) so resolveBreakpoints only has to
+// check which breakpoints are active on a relayout, not re-scan custom
+// properties every time.
+type breakpointOverride struct {
+	breakpoint string
+	property   string // "layout" or "visible"
+	value      string
+}
+
+// applyBreakpointCustomProperties scans for the conventional
+// "<property>@<breakpoint>" custom property naming convention (e.g.
+// "layout@narrow": "column", "visible@wide": "false") and precomputes
+// re.breakpointOverrides, the list resolveBreakpoints consults on each
+// relayout. It doesn't itself touch EffectiveLayout/IsVisible -- which
+// breakpoint (if any) should win depends on the current viewport width,
+// known only once Layout runs.
+func applyBreakpointCustomProperties(re *RenderElement) {
+	re.breakpointOverrides = nil
+	re.baseVisible = re.IsVisible
+	for _, cp := range re.Source.CustomProperties {
+		breakpoint, property, ok := splitBreakpointKey(re.Doc.String(cp.KeyIndex))
+		if !ok {
+			continue
+		}
+		re.breakpointOverrides = append(re.breakpointOverrides, breakpointOverride{
+			breakpoint: breakpoint,
+			property:   property,
+			value:      customStringValue(re.Doc, cp),
+		})
+	}
+}
+
+func splitBreakpointKey(key string) (breakpoint, property string, ok bool) {
+	at := strings.IndexByte(key, '@')
+	if at < 0 {
+		return "", "", false
+	}
+	property = key[:at]
+	if property != "layout" && property != "visible" {
+		return "", "", false
+	}
+	return key[at+1:], property, true
+}
+
+// resolveBreakpoints walks root's subtree, evaluating which of
+// breakpoints match viewportWidth and applying every element's matching
+// "layout@"/"visible@" overrides (the last matching override for a given
+// property wins, in declaration order) on top of its un-overridden
+// EffectiveLayout/IsVisible. An element is marked dirty only when this
+// actually changes its resolved layout direction or visibility, not
+// merely because the active breakpoint set changed -- e.g. resizing
+// between two widths that are both "wide" touches nothing.
+func resolveBreakpoints(root *RenderElement, breakpoints []BreakpointDef, viewportWidth float32) {
+	if len(breakpoints) == 0 {
+		return
+	}
+	active := make(map[string]bool, len(breakpoints))
+	for _, b := range breakpoints {
+		if b.Matches(viewportWidth) {
+			active[b.Name] = true
+		}
+	}
+	Walk(root, func(el *RenderElement) bool {
+		if len(el.breakpointOverrides) > 0 {
+			applyActiveBreakpointOverrides(el, active)
+		}
+		return true
+	})
+}
+
+func applyActiveBreakpointOverrides(el *RenderElement, active map[string]bool) {
+	newLayout := el.Source.Layout
+	newVisible := el.baseVisible
+	for _, o := range el.breakpointOverrides {
+		if !active[o.breakpoint] {
+			continue
+		}
+		switch o.property {
+		case "layout":
+			if dir, ok := parseLayoutDirection(o.value); ok {
+				newLayout = newLayout&^krb.LayoutDirectionMask | dir
+			}
+		case "visible":
+			newVisible = o.value == "true"
+		}
+	}
+	if newLayout != el.EffectiveLayout {
+		el.EffectiveLayout = newLayout
+		el.MarkDirty()
+	}
+	if newVisible != el.IsVisible {
+		el.IsVisible = newVisible
+		el.MarkDirty()
+	}
+}
+
+func parseLayoutDirection(value string) (uint8, bool) {
+	switch value {
+	case "row":
+		return krb.LayoutDirectionRow, true
+	case "column":
+		return krb.LayoutDirectionColumn, true
+	case "row-reverse":
+		return krb.LayoutDirectionRowRev, true
+	case "column-reverse":
+		return krb.LayoutDirectionColRev, true
+	default:
+		return 0, false
+	}
+}
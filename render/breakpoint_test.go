@@ -0,0 +1,177 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestParseBreakpoints(t *testing.T) {
+	defs := parseBreakpoints("narrow<600,wide>=600")
+	if len(defs) != 2 {
+		t.Fatalf("got %d breakpoints, want 2: %+v", len(defs), defs)
+	}
+	if defs[0].Name != "narrow" || defs[0].AtLeast || defs[0].Width != 600 {
+		t.Fatalf("defs[0] = %+v, want {narrow false 600}", defs[0])
+	}
+	if defs[1].Name != "wide" || !defs[1].AtLeast || defs[1].Width != 600 {
+		t.Fatalf("defs[1] = %+v, want {wide true 600}", defs[1])
+	}
+	if !defs[0].Matches(400) || defs[0].Matches(600) {
+		t.Fatalf("narrow<600 should match 400 but not 600")
+	}
+	if defs[1].Matches(400) || !defs[1].Matches(600) {
+		t.Fatalf("wide>=600 should match 600 but not 400")
+	}
+}
+
+func TestParseBreakpointsSkipsMalformedEntries(t *testing.T) {
+	defs := parseBreakpoints("narrow<600, garbage, wide>=600")
+	if len(defs) != 2 {
+		t.Fatalf("got %d breakpoints, want 2 (malformed entry skipped): %+v", len(defs), defs)
+	}
+}
+
+// stringCustomProp builds a string-valued krb.CustomProperty referencing
+// strs[valueIdx] as its value, keyed by strs[keyIdx].
+func stringCustomProp(keyIdx, valueIdx uint8) krb.CustomProperty {
+	return krb.CustomProperty{KeyIndex: keyIdx, Type: krb.ValTypeString, Value: []byte{valueIdx}}
+}
+
+func breakpointDoc() *krb.Document {
+	return &krb.Document{Strings: []string{"", "breakpoints", "narrow<600,wide>=600", "layout@narrow", "column", "visible@narrow", "false"}}
+}
+
+func TestResolveBreakpointsOverridesLayoutDirection(t *testing.T) {
+	doc := breakpointDoc()
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	child := newRenderElement(doc, &krb.Element{
+		Type:   krb.ElemTypeContainer,
+		Layout: krb.LayoutDirectionRow,
+		CustomProperties: []krb.CustomProperty{
+			stringCustomProp(3, 4), // "layout@narrow": "column"
+		},
+	})
+	root.Children = []*RenderElement{child}
+	child.Parent = root
+
+	defs := parseBreakpoints("narrow<600,wide>=600")
+	resolveBreakpoints(root, defs, 400)
+	if child.EffectiveLayout&krb.LayoutDirectionMask != krb.LayoutDirectionColumn {
+		t.Fatalf("at width 400 (narrow), expected column direction, got layout byte %#x", child.EffectiveLayout)
+	}
+	if !child.Dirty {
+		t.Fatalf("expected child to be marked dirty when its effective layout changed")
+	}
+
+	child.Dirty = false
+	resolveBreakpoints(root, defs, 800)
+	if child.EffectiveLayout&krb.LayoutDirectionMask != krb.LayoutDirectionRow {
+		t.Fatalf("at width 800 (wide), expected the original row direction, got layout byte %#x", child.EffectiveLayout)
+	}
+	if !child.Dirty {
+		t.Fatalf("expected child to be marked dirty when switching back out of the override")
+	}
+}
+
+func TestResolveBreakpointsOverridesVisibility(t *testing.T) {
+	doc := breakpointDoc()
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			stringCustomProp(5, 6), // "visible@narrow": "false"
+		},
+	})
+	root.Children = []*RenderElement{child}
+	child.Parent = root
+
+	defs := parseBreakpoints("narrow<600,wide>=600")
+	resolveBreakpoints(root, defs, 400)
+	if child.IsVisible {
+		t.Fatalf("expected child hidden at narrow width")
+	}
+
+	resolveBreakpoints(root, defs, 800)
+	if !child.IsVisible {
+		t.Fatalf("expected child visible again once narrow is no longer active")
+	}
+}
+
+func TestResolveBreakpointsLeavesUnchangedElementsUndirtied(t *testing.T) {
+	doc := breakpointDoc()
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	plain := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Children = []*RenderElement{plain}
+	plain.Parent = root
+	plain.Dirty = false
+
+	resolveBreakpoints(root, parseBreakpoints("narrow<600,wide>=600"), 400)
+	if plain.Dirty {
+		t.Fatalf("expected an element with no breakpoint overrides to stay clean")
+	}
+}
+
+func TestWindowConfigFromAppParsesBreakpoints(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "breakpoints", "narrow<600,wide>=600"}}
+	app := &krb.Element{
+		Type: krb.ElemTypeApp,
+		CustomProperties: []krb.CustomProperty{
+			stringCustomProp(1, 2),
+		},
+	}
+	cfg := windowConfigFromApp(doc, app)
+	if len(cfg.Breakpoints) != 2 {
+		t.Fatalf("got %d breakpoints in WindowConfig, want 2: %+v", len(cfg.Breakpoints), cfg.Breakpoints)
+	}
+}
+
+func TestWindowConfigFromAppParsesTargetFPSAndVSync(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "target_fps", "vsync"}}
+	app := &krb.Element{
+		Type: krb.ElemTypeApp,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeByte, Value: []byte{30}},
+			{KeyIndex: 2, Type: krb.ValTypeByte, Value: []byte{1}},
+		},
+	}
+
+	cfg := windowConfigFromApp(doc, app)
+	if cfg.TargetFPS != 30 {
+		t.Fatalf("cfg.TargetFPS = %v, want 30", cfg.TargetFPS)
+	}
+	if !cfg.VSync {
+		t.Fatalf("cfg.VSync = false, want true")
+	}
+}
+
+func TestWindowConfigFromAppDefaultsTargetFPSTo60WhenUnset(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	app := &krb.Element{Type: krb.ElemTypeApp}
+
+	cfg := windowConfigFromApp(doc, app)
+	if cfg.TargetFPS != 60 {
+		t.Fatalf("cfg.TargetFPS = %v, want 60 (DefaultWindowConfig's baseline)", cfg.TargetFPS)
+	}
+	if cfg.VSync {
+		t.Fatalf("cfg.VSync = true, want false by default")
+	}
+}
+
+func TestPrepareTreeExposesDocumentMetadata(t *testing.T) {
+	b := krb.NewDocumentBuilder()
+	b.AddElement(krb.ElemTypeApp).
+		AddProperty(krb.PropIDAuthor, krb.ValTypeString, []byte{b.String("Ada")})
+	doc := b.Build()
+
+	_, cfg, err := PrepareTree(doc)
+	if err != nil {
+		t.Fatalf("PrepareTree: %v", err)
+	}
+	if cfg.DocumentMetadata.Author != "Ada" {
+		t.Fatalf("cfg.DocumentMetadata.Author = %q, want %q", cfg.DocumentMetadata.Author, "Ada")
+	}
+	if !cfg.DocumentMetadata.HasApp {
+		t.Fatalf("cfg.DocumentMetadata.HasApp = false, want true")
+	}
+}
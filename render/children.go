@@ -0,0 +1,111 @@
+package render
+
+// InsertChildAt, AppendChild, RemoveChild and MoveChild are the supported
+// ways to change an element's children after it's been built. Splicing
+// el.Children directly works for read-only traversal but skips two things
+// these methods take care of: unlinking/relinking the Parent pointer on
+// the far end, and marking the affected elements Dirty so the next Layout
+// pass actually re-arranges them instead of reusing a stale cached
+// subtree (see layoutElement's caching in layout.go).
+
+// InsertChildAt inserts child into el's Children at index, re-parenting
+// child away from its current parent first if it has one. index is
+// clamped to [0, len(el.Children)].
+func (el *RenderElement) InsertChildAt(child *RenderElement, index int) {
+	detachFromParent(child)
+
+	if index < 0 {
+		index = 0
+	}
+	if index > len(el.Children) {
+		index = len(el.Children)
+	}
+	el.Children = append(el.Children, nil)
+	copy(el.Children[index+1:], el.Children[index:])
+	el.Children[index] = child
+	child.Parent = el
+	el.MarkDirty()
+}
+
+// AppendChild inserts child as el's last child, re-parenting it away from
+// its current parent first if it has one.
+func (el *RenderElement) AppendChild(child *RenderElement) {
+	el.InsertChildAt(child, len(el.Children))
+}
+
+// RemoveChild unlinks child from el's Children, if present, and marks el
+// dirty. It's a no-op if child isn't currently one of el's children.
+func (el *RenderElement) RemoveChild(child *RenderElement) {
+	idx := indexOfChild(el, child)
+	if idx < 0 {
+		return
+	}
+	el.Children = append(el.Children[:idx], el.Children[idx+1:]...)
+	child.Parent = nil
+	el.MarkDirty()
+}
+
+// MoveChild repositions child, which must already be one of el's
+// children, to newIndex among its siblings, clamped to
+// [0, len(el.Children)-1]. It's a no-op if child isn't one of el's
+// children.
+func (el *RenderElement) MoveChild(child *RenderElement, newIndex int) {
+	idx := indexOfChild(el, child)
+	if idx < 0 {
+		return
+	}
+	el.Children = append(el.Children[:idx], el.Children[idx+1:]...)
+
+	if newIndex < 0 {
+		newIndex = 0
+	}
+	if newIndex > len(el.Children) {
+		newIndex = len(el.Children)
+	}
+	el.Children = append(el.Children, nil)
+	copy(el.Children[newIndex+1:], el.Children[newIndex:])
+	el.Children[newIndex] = child
+	el.MarkDirty()
+}
+
+// BringToFront moves el to the end of its parent's Children, so it draws
+// last (on top of its siblings) and, per HitTest's front-to-back order,
+// is the one a click lands on where it overlaps them. It's how a
+// freshly-opened popup or modal guarantees it's on top without every
+// other element needing an explicit z-index. A root element (no Parent)
+// is unaffected.
+func BringToFront(el *RenderElement) {
+	if el.Parent == nil {
+		return
+	}
+	el.Parent.MoveChild(el, len(el.Parent.Children)-1)
+}
+
+// SendToBack moves el to the start of its parent's Children, the
+// opposite of BringToFront -- e.g. to return a dismissed popup's sibling
+// to drawing underneath everything else again. A root element (no
+// Parent) is unaffected.
+func SendToBack(el *RenderElement) {
+	if el.Parent == nil {
+		return
+	}
+	el.Parent.MoveChild(el, 0)
+}
+
+// detachFromParent unlinks child from whatever element currently parents
+// it, if any, marking that element dirty.
+func detachFromParent(child *RenderElement) {
+	if child.Parent == nil {
+		return
+	}
+	child.Parent.RemoveChild(child)
+}
+
+func indexOfChild(el, child *RenderElement) int {
+	for i, c := range el.Children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func newTestElement(doc *krb.Document) *RenderElement {
+	return newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+}
+
+func TestInsertChildAtReparentsFromPreviousParent(t *testing.T) {
+	doc := &krb.Document{}
+	oldParent := newTestElement(doc)
+	newParent := newTestElement(doc)
+	child := newTestElement(doc)
+
+	oldParent.AppendChild(child)
+	if child.Parent != oldParent || len(oldParent.Children) != 1 {
+		t.Fatalf("setup: child not attached to oldParent")
+	}
+
+	newParent.InsertChildAt(child, 0)
+
+	if child.Parent != newParent {
+		t.Fatalf("child.Parent = %v, want newParent", child.Parent)
+	}
+	if len(oldParent.Children) != 0 {
+		t.Fatalf("oldParent.Children = %v, want empty after reparenting", oldParent.Children)
+	}
+	if len(newParent.Children) != 1 || newParent.Children[0] != child {
+		t.Fatalf("newParent.Children = %v, want [child]", newParent.Children)
+	}
+}
+
+func TestMoveChildReordersWithoutDetaching(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newTestElement(doc)
+	a, b, c := newTestElement(doc), newTestElement(doc), newTestElement(doc)
+	parent.AppendChild(a)
+	parent.AppendChild(b)
+	parent.AppendChild(c)
+
+	parent.MoveChild(c, 0)
+
+	want := []*RenderElement{c, a, b}
+	for i, el := range want {
+		if parent.Children[i] != el {
+			t.Fatalf("parent.Children = %v, want [c, a, b]", parent.Children)
+		}
+	}
+	if c.Parent != parent {
+		t.Fatalf("c.Parent changed across a MoveChild, want it to stay parent")
+	}
+}
+
+func TestBringToFrontMovesElementToEndOfSiblings(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newTestElement(doc)
+	a, b, c := newTestElement(doc), newTestElement(doc), newTestElement(doc)
+	parent.AppendChild(a)
+	parent.AppendChild(b)
+	parent.AppendChild(c)
+	a.Dirty, b.Dirty, c.Dirty = false, false, false
+
+	BringToFront(a)
+
+	want := []*RenderElement{b, c, a}
+	for i, el := range want {
+		if parent.Children[i] != el {
+			t.Fatalf("parent.Children = %v, want [b, c, a]", parent.Children)
+		}
+	}
+	if !parent.Dirty {
+		t.Fatalf("BringToFront did not mark the parent dirty")
+	}
+}
+
+func TestSendToBackMovesElementToStartOfSiblings(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newTestElement(doc)
+	a, b, c := newTestElement(doc), newTestElement(doc), newTestElement(doc)
+	parent.AppendChild(a)
+	parent.AppendChild(b)
+	parent.AppendChild(c)
+
+	SendToBack(c)
+
+	want := []*RenderElement{c, a, b}
+	for i, el := range want {
+		if parent.Children[i] != el {
+			t.Fatalf("parent.Children = %v, want [c, a, b]", parent.Children)
+		}
+	}
+}
+
+func TestBringToFrontAndSendToBackNoOpOnRootElement(t *testing.T) {
+	doc := &krb.Document{}
+	root := newTestElement(doc)
+
+	BringToFront(root)
+	SendToBack(root)
+}
+
+func TestRemoveChildUnlinksParentPointer(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newTestElement(doc)
+	child := newTestElement(doc)
+	parent.AppendChild(child)
+
+	parent.RemoveChild(child)
+
+	if child.Parent != nil {
+		t.Fatalf("child.Parent = %v, want nil after RemoveChild", child.Parent)
+	}
+	if len(parent.Children) != 0 {
+		t.Fatalf("parent.Children = %v, want empty", parent.Children)
+	}
+}
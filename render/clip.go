@@ -0,0 +1,14 @@
+package render
+
+// applyClipCustomProperties reads the conventional "clip_to_parent" custom
+// property into ClipToParent. It's most useful paired with
+// krb.LayoutAbsoluteBit (see layoutFlowChildren), since an absolutely
+// positioned element is otherwise free to draw anywhere relative to its
+// parent's content box.
+func applyClipCustomProperties(re *RenderElement) {
+	for _, cp := range re.Source.CustomProperties {
+		if re.Doc.String(cp.KeyIndex) == "clip_to_parent" {
+			re.ClipToParent = len(cp.Value) > 0 && cp.Value[0] != 0
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestClipToParentCustomProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "clip_to_parent"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Value: []byte{1}},
+		},
+	})
+	if !el.ClipToParent {
+		t.Fatalf("expected ClipToParent to be true")
+	}
+}
+
+func TestAbsoluteChildIsExcludedFromFlowAndPositionedByOffset(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	parent.Width, parent.Height = 200, 100
+
+	child := newRenderElement(doc, &krb.Element{
+		Type:   krb.ElemTypeContainer,
+		Layout: krb.LayoutAbsoluteBit,
+		PosX:   10,
+		PosY:   20,
+	})
+	child.Width, child.Height = 30, 30
+	parent.Children = []*RenderElement{child}
+	child.Parent = parent
+
+	var errs []error
+	layoutFlowChildren(parent, &errs)
+
+	if child.X != 10 || child.Y != 20 {
+		t.Fatalf("absolute child positioned at (%v, %v), want (10, 20)", child.X, child.Y)
+	}
+}
@@ -0,0 +1,69 @@
+package render
+
+import "fmt"
+
+// Color is a renderer-agnostic RGBA color in the 0-255 range per channel.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// Palette is the default 1-byte indexed palette used when
+// krb.FlagExtendedColor is not set. It intentionally mirrors a common
+// 16-color terminal-style palette so KRB files stay tiny on constrained
+// targets.
+var Palette = [16]Color{
+	{0, 0, 0, 255},       // black
+	{128, 0, 0, 255},     // maroon
+	{0, 128, 0, 255},     // green
+	{128, 128, 0, 255},   // olive
+	{0, 0, 128, 255},     // navy
+	{128, 0, 128, 255},   // purple
+	{0, 128, 128, 255},   // teal
+	{192, 192, 192, 255}, // silver
+	{128, 128, 128, 255}, // gray
+	{255, 0, 0, 255},     // red
+	{0, 255, 0, 255},     // lime
+	{255, 255, 0, 255},   // yellow
+	{0, 0, 255, 255},     // blue
+	{255, 0, 255, 255},   // fuchsia
+	{0, 255, 255, 255},   // aqua
+	{255, 255, 255, 255}, // white
+}
+
+// decodeColor interprets a property value as a Color, honoring
+// extendedColor (4-byte RGBA) vs indexed palette (1-byte) encoding.
+func decodeColor(value []byte, extendedColor bool) Color {
+	color, _ := decodeColorRef(value, extendedColor)
+	return color
+}
+
+// colorSource records where a resolved Color came from: a literal RGBA
+// value, a plain palette index, or a theme token. SetTheme only needs to
+// touch fields whose source is a theme token.
+type colorSource struct {
+	isToken bool
+	token   uint8
+}
+
+// decodeColorRef is decodeColor plus the provenance SetTheme needs to
+// know whether a field should be recolored on a theme switch.
+func decodeColorRef(value []byte, extendedColor bool) (Color, colorSource) {
+	if extendedColor {
+		if len(value) < 4 {
+			return Color{}, colorSource{}
+		}
+		return Color{R: value[0], G: value[1], B: value[2], A: value[3]}, colorSource{}
+	}
+	if len(value) < 1 {
+		return Color{}, colorSource{}
+	}
+	idx := value[0]
+	if idx >= ThemeTokenBase {
+		return resolveThemeToken(idx), colorSource{isToken: true, token: idx}
+	}
+	if int(idx) >= len(Palette) {
+		warnOnce(fmt.Sprintf("palette:%d", idx), "render: palette index %d out of range (max %d); using transparent black", idx, len(Palette)-1)
+		return Color{}, colorSource{}
+	}
+	return Palette[idx], colorSource{}
+}
@@ -0,0 +1,222 @@
+package render
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// componentNameKey is the conventional custom property key a KRB compiler
+// emits on a placeholder element to mark it as a component instance (see
+// krb_source_spec.md section 9, "Runtime Interpretation: Component
+// Instantiation").
+const componentNameKey = "_componentName"
+
+// childrenHostID is the conventional element ID a component template uses
+// to mark where a usage's instance children should be re-parented, e.g.
+// Container { id: "children_host" } inside the template.
+const childrenHostID = "children_host"
+
+// defaultMaxComponentDepth and defaultMaxExpandedElements bound how much
+// work a single document's component expansion can do: a component
+// template that nests other components past defaultMaxComponentDepth
+// deep, or whose expansion would create more than
+// defaultMaxExpandedElements RenderElements in total, is almost
+// certainly a mistake (or, for an untrusted document, an attempt to
+// exhaust memory or the call stack) rather than a legitimate deeply
+// nested UI.
+const (
+	defaultMaxComponentDepth   = 64
+	defaultMaxExpandedElements = 50000
+)
+
+// expansionBudget bounds one document's worth of component expansion.
+// Unlike the per-chain expanding map expandComponent also carries,
+// elements is shared across every top-level element expandComponents
+// walks, so a document that's fine at any single element but explosive
+// across many siblings is still caught.
+type expansionBudget struct {
+	maxDepth    int
+	maxElements int
+	elements    int
+}
+
+// expandComponents walks built looking for component-instance placeholders
+// and expands each one in place.
+func expandComponents(doc *krb.Document, built []*RenderElement) error {
+	budget := &expansionBudget{
+		maxDepth:    defaultMaxComponentDepth,
+		maxElements: defaultMaxExpandedElements,
+		elements:    len(built),
+	}
+	for _, el := range built {
+		if err := expandComponent(doc, el, map[string]bool{}, 0, budget); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandComponent turns a single component-instance placeholder into its
+// full template subtree. The placeholder keeps its identity as the root of
+// the instantiated subtree -- its Parent's Children slice already points
+// at it, so replacing it outright would mean walking back up to fix that
+// up -- but it gains the template's structure beneath it. Standard
+// properties from the placeholder keep winning over the template root's
+// own defaults, matching the precedence KRY usage tags expect. Any
+// children the placeholder already had (the "instance children" from its
+// KRY usage) are re-parented into the template's children_host slot, if
+// the template declares one; otherwise they stay direct children of the
+// instantiated root.
+// expanding is the set of component names currently being expanded along
+// the current call chain, so a component that (directly or transitively)
+// instantiates itself is caught instead of recursing until a stack
+// overflow. A name is added before recursing into its template and
+// removed afterward, so it only guards against an element's own
+// ancestors, not unrelated sibling subtrees that happen to use the same
+// component. depth is how many component templates deep the current
+// chain already is, and budget tracks (and caps) the total number of
+// RenderElements expansion has created for the whole document; either
+// limit being exceeded aborts expansion with an error rather than
+// growing the tree or the call stack without bound.
+func expandComponent(doc *krb.Document, el *RenderElement, expanding map[string]bool, depth int, budget *expansionBudget) error {
+	name, ok := componentName(doc, el)
+	if !ok {
+		return nil
+	}
+	if expanding[name] {
+		log.Printf("render: component %q instantiates itself (directly or transitively); leaving element %d as a plain element", name, el.Source.Index)
+		return nil
+	}
+	if depth >= budget.maxDepth {
+		return fmt.Errorf("render: component expansion exceeded max depth (%d) at element %d (component %q)", budget.maxDepth, el.Source.Index, name)
+	}
+	def, ok := lookupComponentDef(doc, name)
+	if !ok {
+		log.Printf("render: element %d references unknown component %q; leaving as a plain element", el.Source.Index, name)
+		return nil
+	}
+
+	added := len(def.Elements) - 1
+	if budget.elements+added > budget.maxElements {
+		return fmt.Errorf("render: component expansion exceeded max element count (%d)", budget.maxElements)
+	}
+	budget.elements += added
+
+	applyComponentRootProperties(doc, el, def)
+
+	instanceChildren := el.Children
+	el.Children = nil
+
+	template := make([]*RenderElement, len(def.Elements))
+	template[0] = el
+	for i := 1; i < len(def.Elements); i++ {
+		template[i] = newRenderElement(doc, def.Elements[i])
+	}
+	for i, tmplEl := range def.Elements {
+		for _, childIdx := range tmplEl.Children {
+			if childIdx < 0 || childIdx >= len(template) {
+				continue
+			}
+			child := template[childIdx]
+			child.Parent = template[i]
+			template[i].Children = append(template[i].Children, child)
+		}
+	}
+
+	host := findChildrenHost(template)
+	if host == nil {
+		host = el
+	}
+	for _, c := range instanceChildren {
+		c.Parent = host
+	}
+	host.Children = append(host.Children, instanceChildren...)
+
+	// A component's template may itself place an instance of another
+	// component; expand those too.
+	expanding[name] = true
+	for _, c := range template[1:] {
+		if err := expandComponent(doc, c, expanding, depth+1, budget); err != nil {
+			delete(expanding, name)
+			return err
+		}
+	}
+	delete(expanding, name)
+	return nil
+}
+
+// applyComponentRootProperties resolves el's standard properties in the
+// single precedence order a KRY usage tag's overrides are documented to
+// follow: template style < template direct < instance style < instance
+// direct. el arrives already carrying its instance style/direct
+// properties from newRenderElement, applied before the template's own
+// were known; resetting and replaying all four layers in order here,
+// rather than layering the template on top of whatever newRenderElement
+// already resolved, is what makes the winner of each conflict
+// (background color, padding, template text vs. instance text, ...)
+// predictable regardless of which layer declares it.
+func applyComponentRootProperties(doc *krb.Document, el *RenderElement, def krb.ComponentDef) {
+	resetToBaseDefaults(el)
+	if def.Root.StyleID != 0 {
+		if style, ok := lookupStyle(doc, def.Root.StyleID); ok {
+			applyProperties(el, style.Properties)
+		}
+	}
+	applyProperties(el, def.Root.Properties)
+	if el.Source.StyleID != 0 {
+		if style, ok := lookupStyle(doc, el.Source.StyleID); ok {
+			applyProperties(el, style.Properties)
+		}
+	}
+	applyProperties(el, el.Source.Properties)
+	applyCustomProperties(el)
+	applyInteractiveCustomProperties(el)
+	applyImageCustomProperties(el)
+	applyClipCustomProperties(el)
+	applyTextCustomProperties(el)
+	applyLocaleCustomProperties(el)
+	applyAnchorCustomProperties(el)
+	applySizingCustomProperties(el)
+	applyBreakpointCustomProperties(el)
+}
+
+// ComponentName reports the component name el was expanded from, if
+// any. Expansion doesn't clear the placeholder's original
+// "_componentName" custom property from el.Source, so this keeps
+// working on an already-expanded element -- useful for a backend that
+// wants to key behavior (e.g. a draw hook) off which component produced
+// an element.
+func ComponentName(el *RenderElement) (string, bool) {
+	return componentName(el.Doc, el)
+}
+
+func componentName(doc *krb.Document, el *RenderElement) (string, bool) {
+	for _, cp := range el.Source.CustomProperties {
+		if doc.String(cp.KeyIndex) == componentNameKey {
+			return customStringValue(doc, cp), true
+		}
+	}
+	return "", false
+}
+
+func lookupComponentDef(doc *krb.Document, name string) (krb.ComponentDef, bool) {
+	for _, def := range doc.ComponentDefs {
+		if doc.String(def.NameIndex) == name {
+			return def, true
+		}
+	}
+	return krb.ComponentDef{}, false
+}
+
+// findChildrenHost returns the element in a flattened template carrying
+// the conventional childrenHostID, if any.
+func findChildrenHost(template []*RenderElement) *RenderElement {
+	for _, e := range template {
+		if e.ID == childrenHostID {
+			return e
+		}
+	}
+	return nil
+}
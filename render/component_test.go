@@ -0,0 +1,381 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// defaultBudget returns an expansionBudget with the package's real
+// limits, for tests that aren't exercising the limits themselves.
+func defaultBudget() *expansionBudget {
+	return &expansionBudget{maxDepth: defaultMaxComponentDepth, maxElements: defaultMaxExpandedElements}
+}
+
+func TestExpandComponentReparentsInstanceChildrenIntoNamedHost(t *testing.T) {
+	doc := &krb.Document{
+		Strings: []string{"", "_componentName", "Card", "children_host"},
+	}
+
+	hostTmpl := &krb.Element{Index: 1, Type: krb.ElemTypeContainer, ID: 3}
+	rootTmpl := &krb.Element{Index: 0, Type: krb.ElemTypeContainer, Children: []int{1}}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{
+			NameIndex: 2,
+			Elements:  []*krb.Element{rootTmpl, hostTmpl},
+			Root:      rootTmpl,
+		},
+	}
+
+	instanceChild := &krb.Element{Index: 10, Type: krb.ElemTypeText}
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+
+	el := newRenderElement(doc, placeholder)
+	child := newRenderElement(doc, instanceChild)
+	child.Parent = el
+	el.Children = []*RenderElement{child}
+
+	expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+
+	if len(el.Children) != 1 {
+		t.Fatalf("root.Children = %d, want 1 (the template's children_host)", len(el.Children))
+	}
+	host := el.Children[0]
+	if host.ID != "children_host" {
+		t.Fatalf("root's only child has ID %q, want children_host", host.ID)
+	}
+	if len(host.Children) != 1 || host.Children[0] != child {
+		t.Fatalf("host.Children = %v, want [instance child]", host.Children)
+	}
+	if child.Parent != host {
+		t.Fatalf("instance child's Parent was not updated to the host")
+	}
+}
+
+// componentPrecedenceDoc builds a document with one component ("Widget")
+// whose root template carries a style (color index 1) and a direct
+// bg_color (index 2), and a placeholder/instance that can similarly carry
+// an instance style (index 3) and a direct bg_color (index 4), so each
+// layer's contribution to the final resolved property is distinguishable.
+func componentPrecedenceDoc() (*krb.Document, *krb.ComponentDef) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "Widget"}}
+	doc.Styles = []krb.Style{
+		{ID: 1, Properties: []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{1}}}},
+		{ID: 3, Properties: []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{3}}}},
+	}
+	rootTmpl := &krb.Element{
+		Index:   0,
+		Type:    krb.ElemTypeContainer,
+		StyleID: 1,
+	}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{NameIndex: 2, Elements: []*krb.Element{rootTmpl}, Root: rootTmpl},
+	}
+	return doc, &doc.ComponentDefs[0]
+}
+
+func componentPlaceholder(styleID uint8, directBg bool) *krb.Element {
+	el := &krb.Element{
+		Index:   0,
+		Type:    krb.ElemTypeContainer,
+		StyleID: styleID,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	if directBg {
+		el.Properties = []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{4}}}
+	}
+	return el
+}
+
+func TestExpandComponentPrecedence_TemplateDirectBeatsTemplateStyle(t *testing.T) {
+	doc, _ := componentPrecedenceDoc()
+	doc.ComponentDefs[0].Root.Properties = []krb.Property{
+		{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{2}},
+	}
+	placeholder := componentPlaceholder(0, false)
+	el := newRenderElement(doc, placeholder)
+
+	expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+
+	if el.BgColor != Palette[2] {
+		t.Fatalf("BgColor = %v, want template direct color %v (beating template style)", el.BgColor, Palette[2])
+	}
+}
+
+func TestExpandComponentPrecedence_InstanceStyleBeatsTemplateDirect(t *testing.T) {
+	doc, _ := componentPrecedenceDoc()
+	doc.ComponentDefs[0].Root.Properties = []krb.Property{
+		{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{2}},
+	}
+	placeholder := componentPlaceholder(3, false)
+	el := newRenderElement(doc, placeholder)
+
+	expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+
+	if el.BgColor != Palette[3] {
+		t.Fatalf("BgColor = %v, want instance style color %v (beating template direct)", el.BgColor, Palette[3])
+	}
+}
+
+func TestExpandComponentPrecedence_InstanceDirectBeatsEverything(t *testing.T) {
+	doc, _ := componentPrecedenceDoc()
+	doc.ComponentDefs[0].Root.Properties = []krb.Property{
+		{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{2}},
+	}
+	placeholder := componentPlaceholder(3, true)
+	el := newRenderElement(doc, placeholder)
+
+	expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+
+	if el.BgColor != Palette[4] {
+		t.Fatalf("BgColor = %v, want instance direct color %v (beating every other layer)", el.BgColor, Palette[4])
+	}
+}
+
+func TestExpandComponentPrecedence_PaddingAndTextContentFollowSameOrder(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "Widget", "template text", "instance text"}}
+	rootTmpl := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeText,
+		Properties: []krb.Property{
+			{ID: krb.PropIDPadding, Type: krb.ValTypeEdgeInsets, Value: []byte{1, 1, 1, 1}},
+			{ID: krb.PropIDTextContent, Type: krb.ValTypeString, Value: []byte{3}},
+		},
+	}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{NameIndex: 2, Elements: []*krb.Element{rootTmpl}, Root: rootTmpl},
+	}
+
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeText,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+		Properties: []krb.Property{
+			{ID: krb.PropIDPadding, Type: krb.ValTypeEdgeInsets, Value: []byte{5, 5, 5, 5}},
+			{ID: krb.PropIDTextContent, Type: krb.ValTypeString, Value: []byte{4}},
+		},
+	}
+	el := newRenderElement(doc, placeholder)
+
+	expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+
+	if el.PaddingDim[0].Value != 5 {
+		t.Fatalf("PaddingDim[0] = %v, want the instance's direct padding (5) to win over the template's (1)", el.PaddingDim[0].Value)
+	}
+	if el.TextContent != "instance text" {
+		t.Fatalf("TextContent = %q, want the instance's direct text to win over the template's", el.TextContent)
+	}
+}
+
+func TestExpandComponentFallsBackToRootWhenNoHostDeclared(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "Plain"}}
+	rootTmpl := &krb.Element{Index: 0, Type: krb.ElemTypeContainer}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{NameIndex: 2, Elements: []*krb.Element{rootTmpl}, Root: rootTmpl},
+	}
+
+	instanceChild := &krb.Element{Index: 10, Type: krb.ElemTypeText}
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+
+	el := newRenderElement(doc, placeholder)
+	child := newRenderElement(doc, instanceChild)
+	child.Parent = el
+	el.Children = []*RenderElement{child}
+
+	expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+
+	if len(el.Children) != 1 || el.Children[0] != child {
+		t.Fatalf("root.Children = %v, want [instance child] appended directly", el.Children)
+	}
+}
+
+func TestExpandComponentAbortsOnSelfReferentialCycle(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "Loop"}}
+	rootTmpl := &krb.Element{Index: 0, Type: krb.ElemTypeContainer, Children: []int{1}}
+	childTmpl := &krb.Element{
+		Index: 1,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{NameIndex: 2, Elements: []*krb.Element{rootTmpl, childTmpl}, Root: rootTmpl},
+	}
+
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	el := newRenderElement(doc, placeholder)
+
+	done := make(chan struct{})
+	go func() {
+		expandComponent(doc, el, map[string]bool{}, 0, defaultBudget())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expandComponent did not return; self-referential cycle was not caught")
+	}
+
+	if len(el.Children) != 1 {
+		t.Fatalf("root.Children = %v, want the template's one child wired up before expansion aborted", el.Children)
+	}
+	if len(el.Children[0].Children) != 0 {
+		t.Fatalf("expected the cyclical child to be left unexpanded, got %d children", len(el.Children[0].Children))
+	}
+}
+
+// componentChainDoc builds a chain of distinctly-named components, each
+// instantiating the next ("A" -> "B" -> "C" -> ... for len(names) links),
+// bottoming out in a plain component with no further instantiation. It's
+// used to exercise the expansion depth limit without tripping the
+// self-referential cycle guard, which only fires when a component
+// reappears along its own chain.
+func componentChainDoc(names ...string) (*krb.Document, *krb.Element) {
+	strings := []string{"", "_componentName"}
+	strings = append(strings, names...)
+	doc := &krb.Document{Strings: strings}
+
+	defs := make([]krb.ComponentDef, len(names))
+	for i := range names {
+		root := &krb.Element{Index: 0, Type: krb.ElemTypeContainer}
+		elements := []*krb.Element{root}
+		if i+1 < len(names) {
+			root.Children = []int{1}
+			elements = append(elements, &krb.Element{
+				Index: 1,
+				Type:  krb.ElemTypeContainer,
+				CustomProperties: []krb.CustomProperty{
+					{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{byte(2 + i + 1)}},
+				},
+			})
+		}
+		defs[i] = krb.ComponentDef{NameIndex: uint8(2 + i), Elements: elements, Root: root}
+	}
+	doc.ComponentDefs = defs
+
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	return doc, placeholder
+}
+
+func TestExpandComponentReturnsErrorWhenMaxDepthExceeded(t *testing.T) {
+	doc, placeholder := componentChainDoc("A", "B", "C")
+	el := newRenderElement(doc, placeholder)
+
+	budget := &expansionBudget{maxDepth: 2, maxElements: defaultMaxExpandedElements}
+	if err := expandComponent(doc, el, map[string]bool{}, 0, budget); err == nil {
+		t.Fatalf("expected an error when the component chain nests deeper than maxDepth")
+	}
+}
+
+func TestExpandComponentSucceedsWithinMaxDepth(t *testing.T) {
+	doc, placeholder := componentChainDoc("A", "B", "C")
+	el := newRenderElement(doc, placeholder)
+
+	budget := &expansionBudget{maxDepth: 3, maxElements: defaultMaxExpandedElements}
+	if err := expandComponent(doc, el, map[string]bool{}, 0, budget); err != nil {
+		t.Fatalf("expandComponent returned an unexpected error: %v", err)
+	}
+}
+
+func TestExpandComponentReturnsErrorWhenMaxElementsExceeded(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "Big"}}
+	rootTmpl := &krb.Element{Index: 0, Type: krb.ElemTypeContainer, Children: []int{1, 2}}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{
+			NameIndex: 2,
+			Root:      rootTmpl,
+			Elements: []*krb.Element{
+				rootTmpl,
+				{Index: 1, Type: krb.ElemTypeContainer},
+				{Index: 2, Type: krb.ElemTypeContainer},
+			},
+		},
+	}
+
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	el := newRenderElement(doc, placeholder)
+
+	budget := &expansionBudget{maxDepth: defaultMaxComponentDepth, maxElements: 1}
+	if err := expandComponent(doc, el, map[string]bool{}, 0, budget); err == nil {
+		t.Fatalf("expected an error when a single expansion would exceed maxElements")
+	}
+}
+
+func TestComponentNameSurvivesExpansion(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "Widget"}}
+	rootTmpl := &krb.Element{Index: 0, Type: krb.ElemTypeContainer}
+	doc.ComponentDefs = []krb.ComponentDef{
+		{NameIndex: 2, Elements: []*krb.Element{rootTmpl}, Root: rootTmpl},
+	}
+	placeholder := &krb.Element{
+		Index: 0,
+		Type:  krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	el := newRenderElement(doc, placeholder)
+
+	if _, ok := ComponentName(el); !ok {
+		t.Fatalf("expected ComponentName to resolve before expansion")
+	}
+
+	if err := expandComponent(doc, el, map[string]bool{}, 0, defaultBudget()); err != nil {
+		t.Fatalf("expandComponent: %v", err)
+	}
+
+	name, ok := ComponentName(el)
+	if !ok || name != "Widget" {
+		t.Fatalf("ComponentName(el) after expansion = (%q, %v), want (\"Widget\", true)", name, ok)
+	}
+}
+
+func TestPrepareTreePropagatesComponentExpansionDepthError(t *testing.T) {
+	names := make([]string, defaultMaxComponentDepth+2)
+	for i := range names {
+		names[i] = fmt.Sprintf("C%d", i)
+	}
+	doc, placeholder := componentChainDoc(names...)
+	doc.Elements = []*krb.Element{placeholder}
+
+	if _, _, err := PrepareTree(doc); err == nil {
+		t.Fatalf("expected PrepareTree to report an error for a component chain deeper than defaultMaxComponentDepth")
+	}
+}
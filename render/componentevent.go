@@ -0,0 +1,96 @@
+package render
+
+// ComponentEvent describes one dispatched interaction handed to a
+// ComponentEventHandler, carrying everything the handler needs to react
+// without re-querying a backend's own input globals -- which breaks
+// under InjectInput or a render-to-texture offset, since neither one
+// touches raylib's real mouse position. ScreenX/ScreenY and the
+// modifiers are the same InputState fields the dispatcher used for the
+// frame; LocalX/LocalY are that point converted into the handled
+// element's own coordinate space via ScreenToElement.
+type ComponentEvent struct {
+	Type                   uint8
+	ScreenX, ScreenY       float32
+	LocalX, LocalY         float32
+	Button                 MouseButton
+	Shift, Ctrl, Alt, Meta bool
+}
+
+// ComponentEventHandler lets application code intercept dispatched
+// events on the root of every element expanded from a given component
+// (see ComponentName), the event-dispatch analog of raylib.DrawHook. A
+// TabBar, for instance, can pick which tab was clicked from evt.LocalX
+// instead of hit-testing its children itself.
+type ComponentEventHandler interface {
+	HandleEvent(el *RenderElement, evt ComponentEvent)
+}
+
+// legacyComponentEventHandler is HandleEvent's original two-argument
+// signature, which only reported the element and event type.
+// RegisterComponentEventHandler still detects and calls implementations
+// of it during the deprecation window; new code should implement
+// ComponentEventHandler instead.
+type legacyComponentEventHandler interface {
+	HandleEvent(el *RenderElement, eventType uint8)
+}
+
+// componentEventHandlers maps a component name (see ComponentName) to
+// the handler registered for it, exactly the way raylib.drawHooks maps
+// one to a DrawHook.
+var componentEventHandlers = map[string]interface{}{}
+
+// RegisterComponentEventHandler binds componentName to handler, which
+// must implement ComponentEventHandler or, during the deprecation
+// window, legacyComponentEventHandler. Passing a nil handler removes
+// whatever was registered. It panics if handler implements neither --
+// the same "fail loudly at registration, not at dispatch" contract as a
+// nil HandlerFunc silently doing nothing would otherwise violate.
+func RegisterComponentEventHandler(componentName string, handler interface{}) {
+	if handler == nil {
+		delete(componentEventHandlers, componentName)
+		return
+	}
+	switch handler.(type) {
+	case ComponentEventHandler, legacyComponentEventHandler:
+		componentEventHandlers[componentName] = handler
+	default:
+		panic("render: RegisterComponentEventHandler: handler implements neither ComponentEventHandler nor the legacy two-argument HandleEvent(el, eventType)")
+	}
+}
+
+// dispatchComponentEvent walks up from hit to find the nearest
+// ancestor-or-self expanded from a component with a registered handler,
+// and invokes it once with position data derived from state. It's a
+// no-op if hit is nil or no ancestor's component has a handler
+// registered.
+func dispatchComponentEvent(hit *RenderElement, eventType uint8, state InputState, button MouseButton) {
+	for el := hit; el != nil; el = el.Parent {
+		name, ok := ComponentName(el)
+		if !ok {
+			continue
+		}
+		handler, ok := componentEventHandlers[name]
+		if !ok {
+			continue
+		}
+		switch h := handler.(type) {
+		case ComponentEventHandler:
+			localX, localY, _ := ScreenToElement(el, state.MouseX, state.MouseY)
+			h.HandleEvent(el, ComponentEvent{
+				Type:    eventType,
+				ScreenX: state.MouseX,
+				ScreenY: state.MouseY,
+				LocalX:  localX,
+				LocalY:  localY,
+				Button:  button,
+				Shift:   state.Shift,
+				Ctrl:    state.Ctrl,
+				Alt:     state.Alt,
+				Meta:    state.Meta,
+			})
+		case legacyComponentEventHandler:
+			h.HandleEvent(el, eventType)
+		}
+		return
+	}
+}
@@ -0,0 +1,136 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// tabBarElement builds a "TabBar"-named container with 3 equal-width tab
+// children laid out left to right, the same shape ComponentName resolves
+// for a real expanded component without requiring an actual
+// krb.ComponentDef.
+func tabBarElement() (*RenderElement, []*RenderElement) {
+	doc := &krb.Document{Strings: []string{"", "_componentName", "TabBar"}}
+	tabBar := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	})
+	tabBar.X, tabBar.Y, tabBar.Width, tabBar.Height = 0, 0, 90, 20
+
+	tabs := make([]*RenderElement, 3)
+	for i := range tabs {
+		tab := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Index: i})
+		tab.X, tab.Y, tab.Width, tab.Height = float32(i*30), 0, 30, 20
+		tab.Parent = tabBar
+		tabs[i] = tab
+	}
+	tabBar.Children = tabs
+	Layout([]*RenderElement{tabBar}, tabBar.Width, tabBar.Height)
+	return tabBar, tabs
+}
+
+// tabSelector is a ComponentEventHandler standing in for a real TabBar
+// component: it picks the clicked tab from evt.LocalX/30 (each tab is
+// 30px wide) rather than re-deriving mouse position through a backend's
+// own input globals.
+type tabSelector struct {
+	selected int
+}
+
+func (s *tabSelector) HandleEvent(el *RenderElement, evt ComponentEvent) {
+	if evt.Type != krb.EventTypeClick {
+		return
+	}
+	s.selected = int(evt.LocalX / 30)
+}
+
+func TestComponentEventHandlerSelectsTabFromLocalCoordinates(t *testing.T) {
+	tabBar, _ := tabBarElement()
+	selector := &tabSelector{}
+	RegisterComponentEventHandler("TabBar", selector)
+	t.Cleanup(func() { RegisterComponentEventHandler("TabBar", nil) })
+
+	var d InputDispatcher
+	// Screen x=45 falls inside the second tab child (its 30-60px range);
+	// TabBar itself sits at screen x=0, so LocalX equals ScreenX here.
+	d.Dispatch([]*RenderElement{tabBar}, InputState{MouseX: 45, MouseY: 10, MouseDown: [3]bool{true}})
+	d.Dispatch([]*RenderElement{tabBar}, InputState{MouseX: 45, MouseY: 10})
+
+	if selector.selected != 1 {
+		t.Fatalf("selected = %d, want 1 (tab under x=45)", selector.selected)
+	}
+}
+
+func TestComponentEventHandlerLocalCoordinatesMatchScreenAtOrigin(t *testing.T) {
+	tabBar, _ := tabBarElement()
+	var got ComponentEvent
+	RegisterComponentEventHandler("TabBar", componentEventHandlerFunc(func(el *RenderElement, evt ComponentEvent) {
+		got = evt
+	}))
+	t.Cleanup(func() { RegisterComponentEventHandler("TabBar", nil) })
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{tabBar}, InputState{MouseX: 45, MouseY: 10, MouseDown: [3]bool{true}})
+
+	if got.ScreenX != 45 || got.LocalX != 45 {
+		t.Fatalf("root TabBar's local coordinates should match screen here (no offset): got ScreenX=%v LocalX=%v", got.ScreenX, got.LocalX)
+	}
+	if got.Type != krb.EventTypePress {
+		t.Fatalf("Type = %v, want EventTypePress", got.Type)
+	}
+}
+
+// componentEventHandlerFunc adapts a plain function to ComponentEventHandler,
+// the same func-to-interface convenience HandlerFunc gives RegisterHandler.
+type componentEventHandlerFunc func(el *RenderElement, evt ComponentEvent)
+
+func (f componentEventHandlerFunc) HandleEvent(el *RenderElement, evt ComponentEvent) { f(el, evt) }
+
+// legacyTabHandler implements only the old two-argument HandleEvent
+// signature, exercising RegisterComponentEventHandler's deprecation-window
+// compatibility path.
+type legacyTabHandler struct {
+	calls []uint8
+}
+
+func (h *legacyTabHandler) HandleEvent(el *RenderElement, eventType uint8) {
+	h.calls = append(h.calls, eventType)
+}
+
+func TestRegisterComponentEventHandlerCallsLegacyTwoArgumentSignature(t *testing.T) {
+	tabBar, _ := tabBarElement()
+	legacy := &legacyTabHandler{}
+	RegisterComponentEventHandler("TabBar", legacy)
+	t.Cleanup(func() { RegisterComponentEventHandler("TabBar", nil) })
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{tabBar}, InputState{MouseX: 5, MouseY: 5, MouseDown: [3]bool{true}})
+
+	if len(legacy.calls) != 1 || legacy.calls[0] != krb.EventTypePress {
+		t.Fatalf("calls = %v, want a single EventTypePress", legacy.calls)
+	}
+}
+
+func TestRegisterComponentEventHandlerNilRemoves(t *testing.T) {
+	RegisterComponentEventHandler("Test", componentEventHandlerFunc(func(*RenderElement, ComponentEvent) {}))
+	if _, ok := componentEventHandlers["Test"]; !ok {
+		t.Fatalf("expected a handler registered for %q", "Test")
+	}
+
+	RegisterComponentEventHandler("Test", nil)
+	if _, ok := componentEventHandlers["Test"]; ok {
+		t.Fatalf("expected RegisterComponentEventHandler(name, nil) to remove the handler")
+	}
+}
+
+func TestRegisterComponentEventHandlerPanicsOnUnknownInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a handler implementing neither interface")
+		}
+	}()
+	RegisterComponentEventHandler("Test", struct{}{})
+}
@@ -0,0 +1,23 @@
+package render
+
+// Cursor names a mouse cursor shape, decoded from the PropIDCursor
+// standard property (VAL_TYPE_ENUM; see krb_source_spec.md's Standard
+// Properties section). A backend maps these onto whatever native cursor
+// shapes it has, falling back to CursorDefault for any it doesn't
+// support (e.g. raylib has no distinct "wait" or "help" cursor).
+type Cursor uint8
+
+const (
+	CursorDefault Cursor = iota
+	CursorPointer
+	CursorText
+	CursorCrosshair
+	CursorMove
+	CursorResizeNS
+	CursorResizeEW
+	CursorResizeNESW
+	CursorResizeNWSE
+	CursorWait
+	CursorHelp
+	CursorNotAllowed
+)
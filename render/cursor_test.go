@@ -0,0 +1,23 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestCursorPropertyDecodesAndDefaultsToDefault(t *testing.T) {
+	doc := &krb.Document{}
+	plain := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	if plain.Cursor != CursorDefault {
+		t.Fatalf("expected an element with no Cursor property to default to CursorDefault, got %v", plain.Cursor)
+	}
+
+	resize := newRenderElement(doc, &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDCursor, Type: krb.ValTypeEnum, Value: []byte{byte(CursorResizeNWSE)}}},
+	})
+	if resize.Cursor != CursorResizeNWSE {
+		t.Fatalf("Cursor = %v, want CursorResizeNWSE", resize.Cursor)
+	}
+}
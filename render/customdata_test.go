@@ -0,0 +1,58 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestCustomDataBlobIsExposedVerbatim(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	payload := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+	el := newRenderElement(doc, &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDCustomDataBlob, Type: krb.ValTypeCustom, Value: payload}},
+	})
+	if !bytes.Equal(el.CustomData, payload) {
+		t.Fatalf("CustomData = %x, want %x", el.CustomData, payload)
+	}
+}
+
+func TestCustomDataBlobIsACopyNotAnAliasOfTheSourceProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	payload := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+	source := &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDCustomDataBlob, Type: krb.ValTypeCustom, Value: payload}},
+	}
+	el := newRenderElement(doc, source)
+
+	el.CustomData[0] = 0xFF
+	if source.Properties[0].Value[0] != 0xDE {
+		t.Fatalf("mutating el.CustomData corrupted the source krb.Element's property bytes")
+	}
+}
+
+func TestCustomDataBlobsCollectsEveryBlobOnTheElement(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	first := []byte{1, 2}
+	second := []byte{3, 4, 5}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeContainer,
+		Properties: []krb.Property{
+			{ID: krb.PropIDCustomDataBlob, Type: krb.ValTypeCustom, Value: first},
+			{ID: krb.PropIDCustomDataBlob, Type: krb.ValTypeCustom, Value: second},
+		},
+	})
+
+	if len(el.CustomDataBlobs) != 2 {
+		t.Fatalf("CustomDataBlobs has %d entries, want 2", len(el.CustomDataBlobs))
+	}
+	if !bytes.Equal(el.CustomDataBlobs[0], first) || !bytes.Equal(el.CustomDataBlobs[1], second) {
+		t.Fatalf("CustomDataBlobs = %x, want [%x %x]", el.CustomDataBlobs, first, second)
+	}
+	if !bytes.Equal(el.CustomData, second) {
+		t.Fatalf("CustomData = %x, want the last blob applied (%x)", el.CustomData, second)
+	}
+}
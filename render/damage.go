@@ -0,0 +1,140 @@
+package render
+
+// FrameKind classifies how a backend handled one frame, for
+// DamageTracker.RecordFrame.
+type FrameKind uint8
+
+const (
+	FrameFull FrameKind = iota
+	FramePartial
+	FrameSkipped
+)
+
+// pendingDamage is an element MarkDirty was called on since the last
+// resolvePending, along with the bounds it had at that moment -- which
+// are still its pre-relayout bounds, since only Layout ever moves or
+// resizes an element.
+type pendingDamage struct {
+	el  *RenderElement
+	old Rect
+}
+
+// DamageTracker accumulates the screen regions that changed since it was
+// last Reset, so a backend can restrict a frame's drawing (e.g. via a
+// scissor rect) to their union instead of redrawing everything, or skip
+// the frame entirely when nothing changed -- see EnableDamageTracking.
+//
+// Tracking is an approximation, not a pixel-perfect diff: it covers an
+// element's own before/after bounds, but not a sibling that merely
+// shifted position because an earlier, resized sibling pushed it over
+// (see shiftSubtree) without itself being marked dirty. That undamaged
+// sliver is a correctness risk only for a backend that clips strictly to
+// the union; one that's willing to over-draw slightly, or that falls
+// back to a full redraw whenever damage looks unreasonably large, is
+// unaffected.
+type DamageTracker struct {
+	rects   []Rect
+	pending []pendingDamage
+
+	FullFrames    int
+	PartialFrames int
+	SkippedFrames int
+}
+
+// activeDamage is nil until EnableDamageTracking is called, the same
+// off-by-default pattern as activeTheme's registry: a caller that never
+// enables damage tracking never pays for its bookkeeping.
+var activeDamage *DamageTracker
+
+// EnableDamageTracking turns on damage tracking and returns the tracker
+// a backend reads once per frame. From this point on, MarkDirty records
+// each dirtied element's current bounds as the "before" half of a
+// damage rect; the next Layout call resolves each into a final rect
+// once the element's post-layout bounds are known.
+func EnableDamageTracking() *DamageTracker {
+	activeDamage = &DamageTracker{}
+	return activeDamage
+}
+
+// DisableDamageTracking turns off damage tracking. ActiveDamageTracker
+// returns nil afterward, and MarkDirty stops paying for bookkeeping.
+func DisableDamageTracking() {
+	activeDamage = nil
+}
+
+// ActiveDamageTracker returns the tracker enabled by
+// EnableDamageTracking, or nil if damage tracking is currently off.
+func ActiveDamageTracker() *DamageTracker {
+	return activeDamage
+}
+
+// noteDirty records el's current bounds as the "before" half of a
+// damage rect. It's called from MarkDirty, before Layout has had a
+// chance to move or resize el.
+func (d *DamageTracker) noteDirty(el *RenderElement) {
+	old, _ := ElementBounds(el)
+	d.pending = append(d.pending, pendingDamage{el: el, old: old})
+}
+
+// resolvePending turns each pending element's before/after bounds into
+// a finalized damage rect. Layout calls this once, after computing
+// every root's post-layout bounds, so it's the caller for both halves
+// of the comparison.
+func (d *DamageTracker) resolvePending() {
+	for _, p := range d.pending {
+		newRect, newOK := ElementBounds(p.el)
+		oldOK := p.old != (Rect{})
+		switch {
+		case newOK && oldOK:
+			d.rects = append(d.rects, unionRect(p.old, newRect))
+		case newOK:
+			d.rects = append(d.rects, newRect)
+		case oldOK:
+			d.rects = append(d.rects, p.old)
+		}
+	}
+	d.pending = d.pending[:0]
+}
+
+// Union returns the smallest rect covering every damaged region
+// recorded since the last Reset, or ok=false if nothing is damaged.
+func (d *DamageTracker) Union() (Rect, bool) {
+	var union Rect
+	found := false
+	for _, r := range d.rects {
+		if !found {
+			union, found = r, true
+			continue
+		}
+		union = unionRect(union, r)
+	}
+	return union, found
+}
+
+// Empty reports whether nothing has been damaged since the last Reset --
+// a backend in idle mode can skip the frame entirely when this is true
+// and layout isn't dirty.
+func (d *DamageTracker) Empty() bool {
+	return len(d.rects) == 0
+}
+
+// Reset clears the accumulated damage. A backend calls this after it's
+// drawn, or deliberately skipped, a frame, so the next one starts clean.
+func (d *DamageTracker) Reset() {
+	d.rects = d.rects[:0]
+}
+
+// RecordFrame lets a backend report how it handled one frame -- full
+// redraw, scissor-restricted partial redraw, or skipped entirely -- so
+// FullFrames/PartialFrames/SkippedFrames make the benefit of damage
+// tracking measurable.
+func (d *DamageTracker) RecordFrame(kind FrameKind) {
+	switch kind {
+	case FrameFull:
+		d.FullFrames++
+	case FramePartial:
+		d.PartialFrames++
+	case FrameSkipped:
+		d.SkippedFrames++
+	}
+}
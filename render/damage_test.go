@@ -0,0 +1,113 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestDamageTrackerEmptyWhenNothingChanged(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 200, 10
+	Layout([]*RenderElement{root}, 200, 10)
+
+	tracker := EnableDamageTracking()
+	defer DisableDamageTracking()
+
+	Layout([]*RenderElement{root}, 200, 10)
+
+	if !tracker.Empty() {
+		t.Fatalf("expected no damage from a relayout with nothing marked dirty")
+	}
+	if _, ok := tracker.Union(); ok {
+		t.Fatalf("Union() ok = true on an empty tracker")
+	}
+}
+
+func TestDamageTrackerRecordsUnionOfBeforeAndAfterBounds(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 200, 10
+
+	a := growChild(1)
+	b := growChild(2)
+	children := []*RenderElement{a, b}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+	Layout([]*RenderElement{root}, 200, 10)
+
+	if a.Width != 100 {
+		t.Fatalf("a.Width before change = %v, want 100", a.Width)
+	}
+
+	tracker := EnableDamageTracking()
+	defer DisableDamageTracking()
+
+	a.GrowFactor = 3
+	a.MarkDirty()
+	Layout([]*RenderElement{root}, 200, 10)
+
+	if a.Width != 150 {
+		t.Fatalf("a.Width after grow change = %v, want 150", a.Width)
+	}
+	union, ok := tracker.Union()
+	if !ok {
+		t.Fatalf("expected damage after changing a's grow factor")
+	}
+	if union.X > 0 || union.X+union.Width < 150 {
+		t.Fatalf("union = %+v, want it to cover at least [0, 150] on the x axis", union)
+	}
+}
+
+func TestDamageTrackerResetClearsAccumulatedRects(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 100, 100
+	Layout([]*RenderElement{root}, 100, 100)
+
+	tracker := EnableDamageTracking()
+	defer DisableDamageTracking()
+
+	root.MarkDirty()
+	Layout([]*RenderElement{root}, 100, 100)
+	if tracker.Empty() {
+		t.Fatalf("expected damage after MarkDirty")
+	}
+
+	tracker.Reset()
+	if !tracker.Empty() {
+		t.Fatalf("expected Reset to clear accumulated damage")
+	}
+}
+
+func TestDamageTrackerRecordFrameCountsByKind(t *testing.T) {
+	tracker := &DamageTracker{}
+	tracker.RecordFrame(FrameFull)
+	tracker.RecordFrame(FramePartial)
+	tracker.RecordFrame(FramePartial)
+	tracker.RecordFrame(FrameSkipped)
+
+	if tracker.FullFrames != 1 || tracker.PartialFrames != 2 || tracker.SkippedFrames != 1 {
+		t.Fatalf("frame counts = (full=%d, partial=%d, skipped=%d), want (1, 2, 1)",
+			tracker.FullFrames, tracker.PartialFrames, tracker.SkippedFrames)
+	}
+}
+
+func TestDisableDamageTrackingStopsBookkeeping(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 100, 100
+	Layout([]*RenderElement{root}, 100, 100)
+
+	EnableDamageTracking()
+	DisableDamageTracking()
+
+	if ActiveDamageTracker() != nil {
+		t.Fatalf("expected ActiveDamageTracker() to be nil after DisableDamageTracking")
+	}
+	root.MarkDirty()
+	Layout([]*RenderElement{root}, 100, 100)
+}
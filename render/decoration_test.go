@@ -0,0 +1,60 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestTextDecorationDecodesUnderlineAndStrikethroughBits(t *testing.T) {
+	doc := &krb.Document{}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		Properties: []krb.Property{
+			{ID: krb.PropIDTextDecoration, Type: krb.ValTypeEnum, Value: []byte{byte(TextDecorationUnderline | TextDecorationStrikethrough)}},
+		},
+	})
+	if el.Decoration&TextDecorationUnderline == 0 {
+		t.Fatalf("expected underline bit set, got %b", el.Decoration)
+	}
+	if el.Decoration&TextDecorationStrikethrough == 0 {
+		t.Fatalf("expected strikethrough bit set, got %b", el.Decoration)
+	}
+}
+
+func TestTextDecorationDecodesShadowOffsetAndColor(t *testing.T) {
+	doc := &krb.Document{Header: krb.Header{Flags: krb.FlagExtendedColor}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		Properties: []krb.Property{
+			{ID: krb.PropIDTextDecoration, Type: krb.ValTypeEnum, Value: []byte{byte(TextDecorationShadow)}},
+			{ID: krb.PropIDTextShadow, Type: krb.ValTypeCustom, Value: []byte{2, 2, 0, 0, 0, 200}},
+		},
+	})
+	if el.Decoration&TextDecorationShadow == 0 {
+		t.Fatalf("expected shadow bit set, got %b", el.Decoration)
+	}
+	if el.TextShadowOffsetX != 2 || el.TextShadowOffsetY != 2 {
+		t.Fatalf("shadow offset = (%v, %v), want (2, 2)", el.TextShadowOffsetX, el.TextShadowOffsetY)
+	}
+	if el.TextShadowColor != (Color{A: 200}) {
+		t.Fatalf("TextShadowColor = %+v, want {A: 200}", el.TextShadowColor)
+	}
+}
+
+func TestTextDecorationDecodesOutlineColor(t *testing.T) {
+	doc := &krb.Document{Header: krb.Header{Flags: krb.FlagExtendedColor}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		Properties: []krb.Property{
+			{ID: krb.PropIDTextDecoration, Type: krb.ValTypeEnum, Value: []byte{byte(TextDecorationOutline)}},
+			{ID: krb.PropIDTextOutlineColor, Type: krb.ValTypeColor, Value: []byte{0, 0, 0, 255}},
+		},
+	})
+	if el.Decoration&TextDecorationOutline == 0 {
+		t.Fatalf("expected outline bit set, got %b", el.Decoration)
+	}
+	if el.TextOutlineColor != (Color{A: 255}) {
+		t.Fatalf("TextOutlineColor = %+v, want {A: 255}", el.TextOutlineColor)
+	}
+}
@@ -0,0 +1,44 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// Dimension is a size-like value that may be an absolute pixel amount or
+// a percentage to be resolved against a containing size at layout time
+// (e.g. gap and padding can now be expressed as a percentage).
+type Dimension struct {
+	Value     float32 // pixels, or a 0.0-1.0 fraction when IsPercent
+	IsPercent bool
+}
+
+// Resolve returns the pixel value of d given the size of whatever it's a
+// percentage of.
+func (d Dimension) Resolve(containingSize float32) float32 {
+	if d.IsPercent {
+		return containingSize * d.Value
+	}
+	return d.Value
+}
+
+// resolvePaddingDims resolves a full top/right/bottom/left padding set.
+// Percentages resolve against the element's own width, matching CSS's
+// rule that padding percentages are always relative to the containing
+// block's width (even for top/bottom), so padding stays consistent when
+// an element is taller than it is wide.
+func resolvePaddingDims(dims [4]Dimension, width float32) [4]float32 {
+	return [4]float32{
+		dims[0].Resolve(width),
+		dims[1].Resolve(width),
+		dims[2].Resolve(width),
+		dims[3].Resolve(width),
+	}
+}
+
+// mainAxisSize returns the size of el's own main layout axis, which is
+// what a percentage Gap resolves against.
+func mainAxisSize(el *RenderElement) float32 {
+	dir := el.EffectiveLayout & krb.LayoutDirectionMask
+	if dir == krb.LayoutDirectionColumn || dir == krb.LayoutDirectionColRev {
+		return el.Height
+	}
+	return el.Width
+}
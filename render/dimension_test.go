@@ -0,0 +1,25 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestPercentagePaddingResolvesAgainstFinalSize(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	rootSrc := &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow,
+		Properties: []krb.Property{
+			{ID: krb.PropIDPadding, Type: krb.ValTypePercentage, Value: []byte{0x1A, 0x00}}, // 26/256 ~= 10%
+		},
+	}
+	root := newRenderElement(doc, rootSrc)
+	root.Width = 200
+	root.Height = 50
+	Layout([]*RenderElement{root}, 200, 50)
+
+	wantPadding := float32(0x1A) / 256.0 * 200
+	if root.Padding[0] != wantPadding {
+		t.Fatalf("padding percentage resolved to %v, want %v", root.Padding[0], wantPadding)
+	}
+}
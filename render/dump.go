@@ -0,0 +1,82 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// elementDump is DumpTreeJSON's per-element JSON shape. Field names are
+// part of the inspector-facing format, so they're kept stable and
+// lowerCamelCase rather than mirroring RenderElement's own Go names.
+type elementDump struct {
+	Type     uint8   `json:"type"`
+	TypeName string  `json:"typeName"`
+	ID       string  `json:"id,omitempty"`
+	X        float32 `json:"x"`
+	Y        float32 `json:"y"`
+	Width    float32 `json:"width"`
+	Height   float32 `json:"height"`
+	Visible  bool    `json:"visible"`
+	StyleID  uint8   `json:"styleId"`
+
+	BgColor     Color `json:"bgColor"`
+	FgColor     Color `json:"fgColor"`
+	BorderColor Color `json:"borderColor"`
+
+	// Decoration and the shadow/outline fields let a headless snapshot
+	// test verify text decoration without ever rendering a pixel; see
+	// RenderElement.Decoration and TextDecorationShadow/Outline.
+	Decoration        TextDecoration `json:"decoration,omitempty"`
+	TextShadowOffsetX float32        `json:"textShadowOffsetX,omitempty"`
+	TextShadowOffsetY float32        `json:"textShadowOffsetY,omitempty"`
+	TextShadowColor   Color          `json:"textShadowColor"`
+	TextOutlineColor  Color          `json:"textOutlineColor"`
+
+	Children []elementDump `json:"children,omitempty"`
+}
+
+// DumpTreeJSON serializes roots -- type, ID, post-layout bounds,
+// effective visibility, style ID, resolved colors, and children -- as
+// indented JSON to w, for snapshot-testing layout results or feeding an
+// external tree inspector. Call it after Layout (or anything that calls
+// Layout, such as a backend's RunLoop) to capture post-layout bounds;
+// called beforehand, X/Y/Width/Height reflect whatever the tree was
+// built with rather than its laid-out state.
+func DumpTreeJSON(w io.Writer, roots []*RenderElement) error {
+	dumps := make([]elementDump, len(roots))
+	for i, root := range roots {
+		dumps[i] = dumpElement(root)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dumps)
+}
+
+func dumpElement(el *RenderElement) elementDump {
+	children := make([]elementDump, 0, len(el.Children))
+	for _, c := range el.Children {
+		children = append(children, dumpElement(c))
+	}
+	return elementDump{
+		Type:              el.Type,
+		TypeName:          krb.ElementTypeString(el.Type),
+		ID:                el.ID,
+		X:                 el.X,
+		Y:                 el.Y,
+		Width:             el.Width,
+		Height:            el.Height,
+		Visible:           el.EffectiveVisible,
+		StyleID:           el.StyleID,
+		BgColor:           el.BgColor,
+		FgColor:           el.FgColor,
+		BorderColor:       el.BorderColor,
+		Decoration:        el.Decoration,
+		TextShadowOffsetX: el.TextShadowOffsetX,
+		TextShadowOffsetY: el.TextShadowOffsetY,
+		TextShadowColor:   el.TextShadowColor,
+		TextOutlineColor:  el.TextOutlineColor,
+		Children:          children,
+	}
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestDumpTreeJSONIncludesBoundsAndTypeName(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.X, root.Y, root.Width, root.Height = 1, 2, 300, 40
+	root.EffectiveVisible = true
+	root.BgColor = Color{R: 10, G: 20, B: 30, A: 255}
+
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	child.Parent = root
+	root.Children = []*RenderElement{child}
+
+	var buf bytes.Buffer
+	if err := DumpTreeJSON(&buf, []*RenderElement{root}); err != nil {
+		t.Fatalf("DumpTreeJSON: %v", err)
+	}
+
+	var dumped []elementDump
+	if err := json.Unmarshal(buf.Bytes(), &dumped); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	if len(dumped) != 1 {
+		t.Fatalf("len(dumped) = %d, want 1 root", len(dumped))
+	}
+	got := dumped[0]
+	if got.TypeName != "Container" {
+		t.Fatalf("TypeName = %q, want %q", got.TypeName, "Container")
+	}
+	if got.X != 1 || got.Y != 2 || got.Width != 300 || got.Height != 40 {
+		t.Fatalf("bounds = %+v, want (1, 2, 300, 40)", got)
+	}
+	if got.BgColor != (Color{R: 10, G: 20, B: 30, A: 255}) {
+		t.Fatalf("BgColor = %+v", got.BgColor)
+	}
+	if len(got.Children) != 1 || got.Children[0].TypeName != "Text" {
+		t.Fatalf("children = %+v, want one Text child", got.Children)
+	}
+}
+
+func TestDumpTreeJSONIncludesTextDecoration(t *testing.T) {
+	doc := &krb.Document{Header: krb.Header{Flags: krb.FlagExtendedColor}}
+	root := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		Properties: []krb.Property{
+			{ID: krb.PropIDTextDecoration, Type: krb.ValTypeEnum, Value: []byte{byte(TextDecorationShadow)}},
+			{ID: krb.PropIDTextShadow, Type: krb.ValTypeCustom, Value: []byte{1, 1, 0, 0, 0, 180}},
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := DumpTreeJSON(&buf, []*RenderElement{root}); err != nil {
+		t.Fatalf("DumpTreeJSON: %v", err)
+	}
+	var dumped []elementDump
+	if err := json.Unmarshal(buf.Bytes(), &dumped); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	got := dumped[0]
+	if got.Decoration&TextDecorationShadow == 0 {
+		t.Fatalf("expected shadow bit in dumped Decoration, got %b", got.Decoration)
+	}
+	if got.TextShadowOffsetX != 1 || got.TextShadowOffsetY != 1 {
+		t.Fatalf("dumped shadow offset = (%v, %v), want (1, 1)", got.TextShadowOffsetX, got.TextShadowOffsetY)
+	}
+	if got.TextShadowColor != (Color{A: 180}) {
+		t.Fatalf("dumped TextShadowColor = %+v, want {A: 180}", got.TextShadowColor)
+	}
+}
+
+func TestDumpTreeJSONMarksInvisibleElements(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.EffectiveVisible = false
+
+	var buf bytes.Buffer
+	if err := DumpTreeJSON(&buf, []*RenderElement{root}); err != nil {
+		t.Fatalf("DumpTreeJSON: %v", err)
+	}
+	var dumped []elementDump
+	if err := json.Unmarshal(buf.Bytes(), &dumped); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	if dumped[0].Visible {
+		t.Fatalf("expected Visible = false")
+	}
+}
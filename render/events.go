@@ -0,0 +1,190 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// HandlerFunc is an application-registered callback for a KRB event
+// (bound by name, via the Callback ID string in the KRB file).
+type HandlerFunc func(*Event)
+
+// MouseButton identifies which physical mouse button produced a click
+// event.
+type MouseButton uint8
+
+const (
+	MouseButtonLeft MouseButton = iota
+	MouseButtonRight
+	MouseButtonMiddle
+)
+
+// Event describes one dispatched interaction. Target is the element the
+// interaction actually happened on; Current is updated as the event
+// bubbles so a handler shared by several elements can tell which one it
+// fired for. Button is only meaningful for click events; it's
+// MouseButtonLeft for anything dispatched through Dispatch, which predates
+// button discrimination -- use DispatchButton to report a different one.
+type Event struct {
+	Type    uint8
+	Target  *RenderElement
+	Current *RenderElement
+	X, Y    float32
+	Button  MouseButton
+
+	stopped bool
+}
+
+// StopPropagation prevents the event from bubbling past the element
+// whose handler is currently running.
+func (e *Event) StopPropagation() { e.stopped = true }
+
+var handlers = map[string]HandlerFunc{}
+
+// RegisterHandler binds name (as referenced by a KRB event's Callback ID
+// string) to fn. Re-registering a name replaces its handler.
+func RegisterHandler(name string, fn HandlerFunc) {
+	handlers[name] = fn
+}
+
+// Dispatch fires an event of the given type starting at target, bubbling
+// up through target's ancestors until a handler calls StopPropagation or
+// the root is reached. It returns the number of handlers invoked.
+func Dispatch(target *RenderElement, eventType uint8, x, y float32) int {
+	return DispatchButton(target, eventType, x, y, MouseButtonLeft)
+}
+
+// DispatchButton is Dispatch, plus which mouse button produced the event,
+// recorded on Event.Button so a handler bound once can still tell a
+// right-click from a left-click. Use EventTypeForButton to pick the KRB
+// event type a backend should dispatch a given button's click as.
+func DispatchButton(target *RenderElement, eventType uint8, x, y float32, button MouseButton) int {
+	evt := &Event{Type: eventType, Target: target, X: x, Y: y, Button: button}
+	invoked := 0
+	for el := target; el != nil; el = el.Parent {
+		if el.Disabled {
+			continue
+		}
+		evt.Current = el
+		if el.eventEnabled(eventType) {
+			if eventType == krb.EventTypeClick && el.OnClick != nil {
+				el.OnClick(evt)
+				invoked++
+				if evt.stopped {
+					break
+				}
+			}
+			for _, ev := range el.Source.Events {
+				if ev.Type != eventType {
+					continue
+				}
+				name := el.Doc.String(ev.CallbackID)
+				if fn, ok := handlers[name]; ok {
+					fn(evt)
+					invoked++
+				} else if name != "" {
+					warnOnce("handler:"+name, "render: no handler registered for callback %q", name)
+				}
+			}
+		}
+		if evt.stopped {
+			break
+		}
+	}
+	return invoked
+}
+
+// SetEventEnabled toggles whether el dispatches eventType's handlers,
+// without touching any other element that shares the same KRB-declared
+// callback name. Unlike Disabled, which takes an element out of dispatch
+// entirely (including skipping it while a descendant's click bubbles
+// through), this only ever affects the exact (el, eventType) pair and
+// leaves el otherwise fully interactive. The state set here survives
+// relayout and SetStyle, and only resets when el itself is rebuilt (i.e.
+// on document reload).
+func SetEventEnabled(el *RenderElement, eventType uint8, enabled bool) {
+	if enabled {
+		delete(el.disabledEvents, eventType)
+		return
+	}
+	if el.disabledEvents == nil {
+		el.disabledEvents = map[uint8]bool{}
+	}
+	el.disabledEvents[eventType] = true
+}
+
+func (el *RenderElement) eventEnabled(eventType uint8) bool {
+	return !el.disabledEvents[eventType]
+}
+
+// eventNamesByToken maps the names used by the conventional
+// "eventsDisabled" custom property onto their krb.EventType* constants.
+var eventNamesByToken = map[string]uint8{
+	"click":       krb.EventTypeClick,
+	"press":       krb.EventTypePress,
+	"release":     krb.EventTypeRelease,
+	"longpress":   krb.EventTypeLongPress,
+	"hover":       krb.EventTypeHover,
+	"focus":       krb.EventTypeFocus,
+	"blur":        krb.EventTypeBlur,
+	"change":      krb.EventTypeChange,
+	"submit":      krb.EventTypeSubmit,
+	"custom":      krb.EventTypeCustom,
+	"rightclick":  krb.EventTypeRightClick,
+	"middleclick": krb.EventTypeMiddleClick,
+}
+
+// applyEventsDisabledCustomProperty reads the conventional
+// "eventsDisabled" custom property -- a comma-separated list of event
+// names, e.g. "click,hover" -- and seeds el's initial per-event dispatch
+// mask via SetEventEnabled. Called once at build time (see
+// newRenderElement), not as part of the restyle chain, so a later
+// SetStyle call can't silently reset it.
+func applyEventsDisabledCustomProperty(re *RenderElement) {
+	for _, cp := range re.Source.CustomProperties {
+		if re.Doc.String(cp.KeyIndex) != "eventsDisabled" {
+			continue
+		}
+		for _, token := range strings.Split(customStringValue(re.Doc, cp), ",") {
+			if eventType, ok := eventNamesByToken[strings.TrimSpace(token)]; ok {
+				SetEventEnabled(re, eventType, false)
+			}
+		}
+	}
+}
+
+// EventTypeForButton returns the conventional KRB event type a backend
+// should dispatch a mouse click as, based on which button produced it.
+func EventTypeForButton(button MouseButton) uint8 {
+	switch button {
+	case MouseButtonRight:
+		return krb.EventTypeRightClick
+	case MouseButtonMiddle:
+		return krb.EventTypeMiddleClick
+	default:
+		return krb.EventTypeClick
+	}
+}
+
+// HitTest returns the deepest element under (x, y) in root's subtree, or
+// nil if the point misses root entirely. Children are tested after their
+// parent and in front-to-back (last-drawn-on-top) order, so an
+// overlapping later sibling wins. An element that isn't EffectiveVisible
+// can't be hit, and neither can anything inside it -- an invisible page
+// in a tab bar shouldn't still capture clicks meant for whatever's drawn
+// behind it.
+func HitTest(root *RenderElement, x, y float32) *RenderElement {
+	if !root.EffectiveVisible {
+		return nil
+	}
+	if x < root.X || x >= root.X+root.Width || y < root.Y || y >= root.Y+root.Height {
+		return nil
+	}
+	for i := len(root.Children) - 1; i >= 0; i-- {
+		if hit := HitTest(root.Children[i], x, y); hit != nil {
+			return hit
+		}
+	}
+	return root
+}
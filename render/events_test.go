@@ -0,0 +1,151 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestDispatchBubblesUntilStopped(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "onParentClick", "onChildClick"}}
+	parentSrc := &krb.Element{Type: krb.ElemTypeContainer, Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 1}}}
+	childSrc := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 2}}}
+
+	parent := newRenderElement(doc, parentSrc)
+	child := newRenderElement(doc, childSrc)
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	var order []string
+	RegisterHandler("onParentClick", func(e *Event) { order = append(order, "parent") })
+	RegisterHandler("onChildClick", func(e *Event) {
+		order = append(order, "child")
+		e.StopPropagation()
+	})
+
+	n := Dispatch(child, krb.EventTypeClick, 0, 0)
+	if n != 1 || len(order) != 1 || order[0] != "child" {
+		t.Fatalf("expected propagation stopped after the child handler, got %v (n=%d)", order, n)
+	}
+}
+
+func TestDispatchButtonRecordsWhichMouseButtonFired(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "onRightClick"}}
+	src := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeRightClick, CallbackID: 1}}}
+	el := newRenderElement(doc, src)
+
+	var got MouseButton
+	RegisterHandler("onRightClick", func(e *Event) { got = e.Button })
+
+	if n := DispatchButton(el, krb.EventTypeRightClick, 0, 0, MouseButtonRight); n != 1 {
+		t.Fatalf("DispatchButton invoked %d handlers, want 1", n)
+	}
+	if got != MouseButtonRight {
+		t.Fatalf("Event.Button = %v, want MouseButtonRight", got)
+	}
+}
+
+func TestHitTestSkipsHiddenElementAndItsChildren(t *testing.T) {
+	doc := &krb.Document{}
+	parentSrc := &krb.Element{Type: krb.ElemTypeContainer}
+	childSrc := &krb.Element{Type: krb.ElemTypeButton}
+
+	parent := newRenderElement(doc, parentSrc)
+	parent.X, parent.Y, parent.Width, parent.Height = 0, 0, 100, 100
+	child := newRenderElement(doc, childSrc)
+	child.X, child.Y, child.Width, child.Height = 10, 10, 20, 20
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	if hit := HitTest(parent, 15, 15); hit != child {
+		t.Fatalf("expected to hit the visible child, got %v", hit)
+	}
+
+	parent.IsVisible = false
+	computeEffectiveVisibility([]*RenderElement{parent})
+	if hit := HitTest(parent, 15, 15); hit != nil {
+		t.Fatalf("expected a hidden parent to hide its children from hit-testing, got %v", hit)
+	}
+}
+
+func TestSetEventEnabledOnlyAffectsTheToggledElement(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "shared"}}
+	aSrc := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 1}}}
+	bSrc := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 1}}}
+	a := newRenderElement(doc, aSrc)
+	b := newRenderElement(doc, bSrc)
+
+	var fired int
+	RegisterHandler("shared", func(e *Event) { fired++ })
+
+	SetEventEnabled(a, krb.EventTypeClick, false)
+
+	if n := Dispatch(a, krb.EventTypeClick, 0, 0); n != 0 {
+		t.Fatalf("Dispatch(a) invoked %d handlers, want 0 (disabled)", n)
+	}
+	if n := Dispatch(b, krb.EventTypeClick, 0, 0); n != 1 {
+		t.Fatalf("Dispatch(b) invoked %d handlers, want 1 (still enabled)", n)
+	}
+	if fired != 1 {
+		t.Fatalf("shared handler fired %d times, want 1", fired)
+	}
+
+	SetEventEnabled(a, krb.EventTypeClick, true)
+	if n := Dispatch(a, krb.EventTypeClick, 0, 0); n != 1 {
+		t.Fatalf("Dispatch(a) invoked %d handlers after re-enabling, want 1", n)
+	}
+}
+
+func TestSetEventEnabledOnlyMasksTheGivenEventType(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "onHover"}}
+	src := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeHover, CallbackID: 1}}}
+	el := newRenderElement(doc, src)
+
+	var fired int
+	RegisterHandler("onHover", func(e *Event) { fired++ })
+
+	SetEventEnabled(el, krb.EventTypeClick, false)
+	if n := Dispatch(el, krb.EventTypeHover, 0, 0); n != 1 {
+		t.Fatalf("Dispatch(hover) invoked %d handlers, want 1 (click mask shouldn't affect hover)", n)
+	}
+}
+
+func TestEventsDisabledCustomPropertySeedsInitialMask(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "eventsDisabled", "click,hover", "onClick"}}
+	src := &krb.Element{
+		Type:   krb.ElemTypeButton,
+		Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 3}},
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	}
+	el := newRenderElement(doc, src)
+
+	var fired int
+	RegisterHandler("onClick", func(e *Event) { fired++ })
+
+	if n := Dispatch(el, krb.EventTypeClick, 0, 0); n != 0 {
+		t.Fatalf("Dispatch(click) invoked %d handlers, want 0 (disabled via eventsDisabled)", n)
+	}
+	if !el.eventEnabled(krb.EventTypeFocus) {
+		t.Fatalf("expected focus to remain enabled; eventsDisabled only named click and hover")
+	}
+
+	SetEventEnabled(el, krb.EventTypeClick, true)
+	if n := Dispatch(el, krb.EventTypeClick, 0, 0); n != 1 {
+		t.Fatalf("Dispatch(click) invoked %d handlers after re-enabling, want 1", n)
+	}
+}
+
+func TestEventTypeForButton(t *testing.T) {
+	cases := map[MouseButton]uint8{
+		MouseButtonLeft:   krb.EventTypeClick,
+		MouseButtonRight:  krb.EventTypeRightClick,
+		MouseButtonMiddle: krb.EventTypeMiddleClick,
+	}
+	for button, want := range cases {
+		if got := EventTypeForButton(button); got != want {
+			t.Fatalf("EventTypeForButton(%v) = %#x, want %#x", button, got, want)
+		}
+	}
+}
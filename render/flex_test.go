@@ -0,0 +1,175 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLayoutReservesFlexBasisBeforeDistributingGrowSpace(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 200, 10
+
+	basisChild := growChild(1)
+	basisChild.FlexBasisDim = Dimension{Value: 50}
+	plainGrow := growChild(2)
+
+	children := []*RenderElement{basisChild, plainGrow}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+
+	Layout([]*RenderElement{root}, 200, 10)
+
+	// 200px available, 50px basis reserved for basisChild, 150px left
+	// split evenly (both grow factor 1): basisChild gets 50+75=125,
+	// plainGrow gets 75.
+	if basisChild.Width != 125 {
+		t.Fatalf("basisChild.Width = %v, want 125 (50 basis + 75 leftover share)", basisChild.Width)
+	}
+	if plainGrow.Width != 75 {
+		t.Fatalf("plainGrow.Width = %v, want 75 (leftover share only)", plainGrow.Width)
+	}
+}
+
+func TestLayoutDistributesGrowSpaceByFactor(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 400, 10
+
+	sidebar := growChild(1)
+	sidebar.GrowFactor = 1
+	main := growChild(2)
+	main.GrowFactor = 3
+
+	children := []*RenderElement{sidebar, main}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+
+	Layout([]*RenderElement{root}, 400, 10)
+
+	if sidebar.Width != 100 {
+		t.Fatalf("sidebar.Width = %v, want 100 (1/4 share of 400)", sidebar.Width)
+	}
+	if main.Width != 300 {
+		t.Fatalf("main.Width = %v, want 300 (3/4 share of 400)", main.Width)
+	}
+}
+
+func TestLayoutGrowFactorEqualSharePreservedWhenUnset(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 300, 10
+
+	children := []*RenderElement{growChild(1), growChild(2), growChild(3)}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+
+	Layout([]*RenderElement{root}, 300, 10)
+
+	for i, c := range children {
+		if c.Width != 100 {
+			t.Fatalf("children[%d].Width = %v, want 100 (equal share of 300 across 3 unweighted grow children)", i, c.Width)
+		}
+	}
+}
+
+func TestLayoutDistributesColumnGrowSpaceByFactorAcrossThreeChildren(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionColumn})
+	root.Width, root.Height = 10, 600
+
+	header := growChild(1)
+	header.GrowFactor = 1
+	body := growChild(2)
+	body.GrowFactor = 2
+	footer := growChild(3)
+	footer.GrowFactor = 1
+
+	children := []*RenderElement{header, body, footer}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+
+	Layout([]*RenderElement{root}, 10, 600)
+
+	// Factors sum to 4, so 600px splits as 1/4, 2/4, 1/4 -- 150, 300, 150.
+	if header.Height != 150 || body.Height != 300 || footer.Height != 150 {
+		t.Fatalf("heights = (%v, %v, %v), want (150, 300, 150)", header.Height, body.Height, footer.Height)
+	}
+}
+
+func TestLayoutOverflowsRatherThanCollapsingGrowTextBelowIntrinsicMinimum(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 60, 10
+
+	left := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText, Layout: krb.LayoutGrowBit})
+	left.TextContent = "Left label" // 10 runes * 8px = 80px intrinsic minimum
+	right := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText, Layout: krb.LayoutGrowBit})
+	right.TextContent = "Right label" // 11 runes * 8px = 88px intrinsic minimum
+
+	children := []*RenderElement{left, right}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+
+	Layout([]*RenderElement{root}, 60, 10)
+
+	// The container is only 60px wide, far narrower than either label's
+	// own intrinsic minimum -- both keep their full minimum width and
+	// the row overflows instead of squeezing either one down to mush.
+	if left.Width != 80 {
+		t.Fatalf("left.Width = %v, want 80 (its intrinsic minimum, not squeezed to fit)", left.Width)
+	}
+	if right.Width != 88 {
+		t.Fatalf("right.Width = %v, want 88 (its intrinsic minimum, not squeezed to fit)", right.Width)
+	}
+}
+
+func TestLayoutRedistributesDeficitWhenOneGrowChildHitsItsMinimum(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow})
+	root.Width, root.Height = 50, 10
+
+	label := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText, Layout: krb.LayoutGrowBit})
+	label.TextContent = "Name" // 4 runes * 8px = 32px intrinsic minimum
+	spacer := growChild(2)
+
+	children := []*RenderElement{label, spacer}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+
+	Layout([]*RenderElement{root}, 50, 10)
+
+	// An even split would give each 25px, below label's 32px minimum, so
+	// label is frozen at 32px and the remaining 18px goes to spacer.
+	if label.Width != 32 {
+		t.Fatalf("label.Width = %v, want 32 (clamped to its minimum)", label.Width)
+	}
+	if spacer.Width != 18 {
+		t.Fatalf("spacer.Width = %v, want 18 (the rest after label's minimum)", spacer.Width)
+	}
+}
+
+func TestEffectiveGrowFactorDefaultsToOne(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	if got := EffectiveGrowFactor(el); got != 1 {
+		t.Fatalf("EffectiveGrowFactor(unset) = %v, want 1", got)
+	}
+	el.GrowFactor = 5
+	if got := EffectiveGrowFactor(el); got != 5 {
+		t.Fatalf("EffectiveGrowFactor(5) = %v, want 5", got)
+	}
+}
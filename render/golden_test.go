@@ -0,0 +1,125 @@
+package render
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// updateGolden regenerates testdata/*.golden.json from the example KRBs'
+// current layout instead of comparing against it -- run as
+// `go test ./render/ -run TestGoldenLayoutMatchesExamples -update` after an
+// intentional layout change.
+var updateGolden = flag.Bool("update", false, "regenerate golden layout files in testdata")
+
+const (
+	goldenViewportWidth  = 800
+	goldenViewportHeight = 600
+)
+
+// goldenNode is the serialized shape of one RenderElement compared against
+// a golden file: name, type, post-layout rect, visibility and text, the
+// fields a layout refactor should (or shouldn't) change. Pointers and
+// anything a backend only assigns at draw time (texture IDs, ...) are
+// deliberately left out, since neither is stable or even meaningful
+// outside a live Renderer.
+type goldenNode struct {
+	ID       string       `json:"id,omitempty"`
+	Type     uint8        `json:"type"`
+	X        float32      `json:"x"`
+	Y        float32      `json:"y"`
+	Width    float32      `json:"width"`
+	Height   float32      `json:"height"`
+	Visible  bool         `json:"visible"`
+	Text     string       `json:"text,omitempty"`
+	Children []goldenNode `json:"children,omitempty"`
+}
+
+func serializeGolden(el *RenderElement) goldenNode {
+	n := goldenNode{
+		ID:      el.ID,
+		Type:    el.Type,
+		X:       el.X,
+		Y:       el.Y,
+		Width:   el.Width,
+		Height:  el.Height,
+		Visible: el.EffectiveVisible,
+		Text:    el.TextContent,
+	}
+	for _, c := range el.Children {
+		n.Children = append(n.Children, serializeGolden(c))
+	}
+	return n
+}
+
+// TestGoldenLayoutMatchesExamples loads every example .krb checked into
+// the repo, lays it out at a fixed viewport with PrepareTree/Layout, and
+// compares the serialized tree against testdata/<name>.golden.json. None
+// of the shipped examples size anything from measured text (every
+// dimension is either explicit or a percentage), so this comparison is
+// already fully deterministic without needing a text measurer.
+func TestGoldenLayoutMatchesExamples(t *testing.T) {
+	matches, err := filepath.Glob("../examples/*.krb")
+	if err != nil {
+		t.Fatalf("glob examples: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Fatalf("no example .krb files found under ../examples")
+	}
+	sort.Strings(matches)
+
+	for _, path := range matches {
+		path := path
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile(%s): %v", path, err)
+			}
+			doc, err := krb.DecodeBytes(data)
+			if err != nil {
+				t.Fatalf("DecodeBytes(%s): %v", path, err)
+			}
+			roots, _, err := PrepareTree(doc)
+			if err != nil {
+				t.Fatalf("PrepareTree(%s): %v", path, err)
+			}
+			Layout(roots, goldenViewportWidth, goldenViewportHeight)
+
+			got := make([]goldenNode, 0, len(roots))
+			for _, root := range roots {
+				got = append(got, serializeGolden(root))
+			}
+			gotJSON, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatalf("marshal golden tree for %s: %v", name, err)
+			}
+			gotJSON = append(gotJSON, '\n')
+
+			goldenPath := filepath.Join("testdata", name+".golden.json")
+			if *updateGolden {
+				if err := os.MkdirAll("testdata", 0o755); err != nil {
+					t.Fatalf("MkdirAll testdata: %v", err)
+				}
+				if err := os.WriteFile(goldenPath, gotJSON, 0o644); err != nil {
+					t.Fatalf("WriteFile(%s): %v", goldenPath, err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("golden file %s missing or unreadable (run with -update to generate it): %v", goldenPath, err)
+			}
+			if string(want) != string(gotJSON) {
+				t.Fatalf("layout for %s no longer matches %s (run with -update to regenerate if intentional)\ngot:\n%s\nwant:\n%s", path, goldenPath, gotJSON, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package render
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// applyHitPaddingCustomProperty reads the conventional "hitPadding"
+// custom property -- a pixel count added to every side of an
+// IsInteractive element's hit-testing rectangle, e.g. "hitPadding": "8"
+// -- into HitPadding. A non-numeric value is ignored, leaving HitPadding
+// at its zero default.
+func applyHitPaddingCustomProperty(re *RenderElement) {
+	for _, cp := range re.Source.CustomProperties {
+		if re.Doc.String(cp.KeyIndex) != "hitPadding" {
+			continue
+		}
+		if v, err := strconv.ParseFloat(strings.TrimSpace(customStringValue(re.Doc, cp)), 32); err == nil {
+			re.HitPadding = float32(v)
+		}
+	}
+}
+
+// expandedHitRect returns the rectangle el is actually hit-tested
+// against: its visual rect, grown (centered on that same visual rect) by
+// HitPadding and/or up to at least minW x minH, whichever is larger. A
+// non-interactive element is never grown -- touch accommodation for a
+// button shouldn't make static content start eating clicks meant for
+// whatever's next to it.
+func expandedHitRect(el *RenderElement, minW, minH float32) (x, y, w, h float32) {
+	if !el.IsInteractive {
+		return el.X, el.Y, el.Width, el.Height
+	}
+	w = el.Width + el.HitPadding*2
+	h = el.Height + el.HitPadding*2
+	if w < minW {
+		w = minW
+	}
+	if h < minH {
+		h = minH
+	}
+	cx := el.X + el.Width/2
+	cy := el.Y + el.Height/2
+	return cx - w/2, cy - h/2, w, h
+}
+
+// collectExpandedHits appends every IsInteractive, EffectiveVisible
+// descendant of root (root included) whose expanded hit rect contains
+// (x, y) to out. Like HitTest, a subtree rooted at an invisible element
+// is skipped entirely.
+func collectExpandedHits(root *RenderElement, x, y, minW, minH float32, out *[]*RenderElement) {
+	if !root.EffectiveVisible {
+		return
+	}
+	if root.IsInteractive {
+		rx, ry, rw, rh := expandedHitRect(root, minW, minH)
+		if x >= rx && x < rx+rw && y >= ry && y < ry+rh {
+			*out = append(*out, root)
+		}
+	}
+	for _, c := range root.Children {
+		collectExpandedHits(c, x, y, minW, minH, out)
+	}
+}
+
+// distanceToRect returns the Euclidean distance from (x, y) to the
+// nearest point of the rectangle (rx, ry, rw, rh), 0 if (x, y) is inside
+// it.
+func distanceToRect(x, y, rx, ry, rw, rh float32) float32 {
+	var dx, dy float32
+	switch {
+	case x < rx:
+		dx = rx - x
+	case x > rx+rw:
+		dx = x - (rx + rw)
+	}
+	switch {
+	case y < ry:
+		dy = ry - y
+	case y > ry+rh:
+		dy = y - (ry + rh)
+	}
+	return float32(math.Hypot(float64(dx), float64(dy)))
+}
+
+// nearestExpandedHit returns whichever of roots' IsInteractive elements
+// has an expanded hit rect (see expandedHitRect) containing (x, y) and
+// the visual rect nearest to it, or nil if none do. Used as a fallback
+// when an exact HitTest misses, so two adjacent touch-expanded buttons
+// resolve a point in their overlap to whichever one the point is
+// actually closest to, instead of always favoring tree order.
+func nearestExpandedHit(roots []*RenderElement, x, y, minW, minH float32) *RenderElement {
+	var candidates []*RenderElement
+	for _, root := range roots {
+		collectExpandedHits(root, x, y, minW, minH, &candidates)
+	}
+	var best *RenderElement
+	var bestDist float32
+	for _, c := range candidates {
+		dist := distanceToRect(x, y, c.X, c.Y, c.Width, c.Height)
+		if best == nil || dist < bestDist {
+			best, bestDist = c, dist
+		}
+	}
+	return best
+}
@@ -0,0 +1,62 @@
+package render
+
+// Translations maps a string-table value (a literal displayed string,
+// or an explicit "@some_key"-style message key used in its place) to a
+// replacement for the active locale. It's consulted only at the handful
+// of sites that resolve literal display text -- TextContent, Tooltip,
+// and the App element's window title -- never for IDs, handler names,
+// component names or style names, which always resolve straight from
+// doc.Strings regardless of the active translations.
+type Translations map[string]string
+
+// resolve returns t's replacement for key, or key itself if t is nil,
+// empty, or has no entry for key -- an untranslated string is simply
+// itself, not an error.
+func (t Translations) resolve(key string) string {
+	if key == "" {
+		return key
+	}
+	if v, ok := t[key]; ok {
+		return v
+	}
+	return key
+}
+
+// activeTranslations is consulted by every PropIDTextContent/
+// PropIDTooltip/PropIDWindowTitle resolution, the same way activeTheme
+// is consulted by every token color resolution; see SetTranslations.
+var activeTranslations Translations
+
+// SetTranslations activates translations as the current locale's string
+// substitutions and re-resolves every element's TextContent and Tooltip
+// across the given trees against it, marking each changed element (and,
+// for a FitContent Text element whose width depends on its own content,
+// its ancestors) dirty so the next Layout/Draw pass picks up the new
+// text. Passing nil (or an empty map) reverts every element to the
+// literal text its KRB file declared -- translations are applied on top
+// of TextKey/TooltipKey at display time, never written back into
+// doc.Strings, so switching locales back and forth is always lossless.
+func SetTranslations(roots []*RenderElement, translations Translations) {
+	activeTranslations = translations
+	for _, root := range roots {
+		retranslateTree(root)
+	}
+}
+
+func retranslateTree(el *RenderElement) {
+	changed := false
+	if resolved := activeTranslations.resolve(el.TextKey); resolved != el.TextContent {
+		el.TextContent = resolved
+		changed = true
+	}
+	if resolved := activeTranslations.resolve(el.TooltipKey); resolved != el.Tooltip {
+		el.Tooltip = resolved
+		changed = true
+	}
+	if changed {
+		el.MarkDirty()
+	}
+	for _, c := range el.Children {
+		retranslateTree(c)
+	}
+}
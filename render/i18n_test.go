@@ -0,0 +1,102 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func translatableTextElement(doc *krb.Document, text string) *RenderElement {
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	el.TextKey = text
+	el.TextContent = text
+	return el
+}
+
+func TestSetTranslationsReplacesTextContent(t *testing.T) {
+	doc := &krb.Document{}
+	el := translatableTextElement(doc, "Hello")
+
+	SetTranslations([]*RenderElement{el}, Translations{"Hello": "Hola"})
+	t.Cleanup(func() { SetTranslations(nil, nil) })
+
+	if el.TextContent != "Hola" {
+		t.Fatalf("TextContent = %q, want %q", el.TextContent, "Hola")
+	}
+	if el.TextKey != "Hello" {
+		t.Fatalf("TextKey = %q, want unchanged %q", el.TextKey, "Hello")
+	}
+}
+
+func TestSetTranslationsSwitchingTwiceIsLossless(t *testing.T) {
+	doc := &krb.Document{}
+	el := translatableTextElement(doc, "Hello")
+	t.Cleanup(func() { SetTranslations(nil, nil) })
+
+	SetTranslations([]*RenderElement{el}, Translations{"Hello": "Hola"})
+	SetTranslations([]*RenderElement{el}, Translations{"Hello": "Bonjour"})
+	if el.TextContent != "Bonjour" {
+		t.Fatalf("TextContent = %q, want %q", el.TextContent, "Bonjour")
+	}
+
+	SetTranslations([]*RenderElement{el}, nil)
+	if el.TextContent != "Hello" {
+		t.Fatalf("TextContent = %q, want original %q restored", el.TextContent, "Hello")
+	}
+}
+
+func TestSetTranslationsLeavesUntranslatedKeysAlone(t *testing.T) {
+	doc := &krb.Document{}
+	el := translatableTextElement(doc, "submit_button")
+	t.Cleanup(func() { SetTranslations(nil, nil) })
+
+	SetTranslations([]*RenderElement{el}, Translations{"Hello": "Hola"})
+	if el.TextContent != "submit_button" {
+		t.Fatalf("TextContent = %q, want unchanged %q (no matching key)", el.TextContent, "submit_button")
+	}
+}
+
+func TestSetTranslationsMarksChangedElementsDirty(t *testing.T) {
+	doc := &krb.Document{}
+	el := translatableTextElement(doc, "Hello")
+	el.laidOut = true
+	el.Dirty = false
+	t.Cleanup(func() { SetTranslations(nil, nil) })
+
+	SetTranslations([]*RenderElement{el}, Translations{"Hello": "Hola"})
+	if !el.Dirty {
+		t.Fatalf("expected element to be marked Dirty after its text changed")
+	}
+}
+
+func TestSetTranslationsChangesFitContentTextWidthOnRelayout(t *testing.T) {
+	doc := &krb.Document{}
+	el := translatableTextElement(doc, "Hi")
+	el.FitContent = true
+	t.Cleanup(func() { SetTranslations(nil, nil) })
+
+	var errs []error
+	layoutElement(el, &errs)
+	shortWidth := el.Width
+
+	SetTranslations([]*RenderElement{el}, Translations{"Hi": "Good afternoon"})
+	layoutElement(el, &errs)
+	longWidth := el.Width
+
+	if longWidth <= shortWidth {
+		t.Fatalf("width after translating to longer text = %v, want greater than %v", longWidth, shortWidth)
+	}
+}
+
+func TestSetTranslationsAppliesToTooltip(t *testing.T) {
+	doc := &krb.Document{}
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeButton})
+	el.TooltipKey = "Save"
+	el.Tooltip = "Save"
+	t.Cleanup(func() { SetTranslations(nil, nil) })
+
+	SetTranslations([]*RenderElement{el}, Translations{"Save": "Guardar"})
+	if el.Tooltip != "Guardar" {
+		t.Fatalf("Tooltip = %q, want %q", el.Tooltip, "Guardar")
+	}
+}
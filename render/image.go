@@ -0,0 +1,45 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// applyImageCustomProperties reads the conventional "image_tint",
+// "image_flip_h", "image_flip_v", "tintIcon" and "grayscale" custom
+// properties (see krb_source_spec.md's Custom Properties section). They're
+// custom properties rather than standard ones because most compiled KRB
+// files never tint, mirror or desaturate an image at all.
+//
+// "tintIcon": "true" is applied after "image_tint" regardless of
+// declaration order, so it always wins: it's a convenience for the common
+// monochrome-icon case (resolve the tint from the element's own FgColor,
+// which SetStyle/theme switches keep current) and a literal "image_tint"
+// alongside it would otherwise race depending on custom property order.
+func applyImageCustomProperties(re *RenderElement) {
+	for _, cp := range re.Source.CustomProperties {
+		switch re.Doc.String(cp.KeyIndex) {
+		case "image_tint":
+			extended := re.Doc.Header.HasFlag(krb.FlagExtendedColor)
+			re.ImageTint, re.imageTintSource = decodeColorRef(cp.Value, extended)
+		case "image_flip_h":
+			re.ImageFlipH = len(cp.Value) > 0 && cp.Value[0] != 0
+		case "image_flip_v":
+			re.ImageFlipV = len(cp.Value) > 0 && cp.Value[0] != 0
+		case "tintIcon":
+			re.TintFromFg = len(cp.Value) > 0 && cp.Value[0] != 0
+		case "grayscale":
+			re.Grayscale = len(cp.Value) > 0 && cp.Value[0] != 0
+		}
+	}
+	if re.TintFromFg {
+		re.ImageTint = re.FgColor
+		re.imageTintSource = re.fgColorSource
+	}
+}
+
+// ResolvedImageTint is the color a backend should actually draw an image
+// with: ImageTint with Opacity multiplied into its alpha channel, rather
+// than ImageTint alone, which doesn't account for opacity.
+func ResolvedImageTint(el *RenderElement) Color {
+	tint := el.ImageTint
+	tint.A = uint8(float32(tint.A) * el.Opacity)
+	return tint
+}
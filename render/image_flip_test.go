@@ -0,0 +1,24 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestImageFlipCustomProperties(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "image_flip_h", "image_flip_v"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeImage,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Value: []byte{1}},
+			{KeyIndex: 2, Value: []byte{0}},
+		},
+	})
+	if !el.ImageFlipH {
+		t.Fatalf("expected ImageFlipH to be true")
+	}
+	if el.ImageFlipV {
+		t.Fatalf("expected ImageFlipV to be false")
+	}
+}
@@ -0,0 +1,75 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestImageTintDefaultsToWhiteAndReadsCustomProperty(t *testing.T) {
+	doc := &krb.Document{
+		Strings:   []string{"", "icon.png", "image_tint"},
+		Resources: []krb.Resource{{NameIndex: 1}},
+	}
+
+	plain := newRenderElement(doc, &krb.Element{
+		Type:       krb.ElemTypeImage,
+		Properties: []krb.Property{{ID: krb.PropIDImageSource, Type: krb.ValTypeResource, Value: []byte{0}}},
+	})
+	if plain.ImagePath != "icon.png" {
+		t.Fatalf("ImagePath = %q, want %q", plain.ImagePath, "icon.png")
+	}
+	if plain.ImageTint != (Color{255, 255, 255, 255}) {
+		t.Fatalf("ImageTint = %+v, want opaque white default", plain.ImageTint)
+	}
+
+	tinted := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeImage,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 2, Value: []byte{9}}, // palette index 9 = red
+		},
+	})
+	if tinted.ImageTint != Palette[9] {
+		t.Fatalf("ImageTint = %+v, want %+v", tinted.ImageTint, Palette[9])
+	}
+}
+
+func TestTintIconResolvesFromForegroundColor(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "tintIcon"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeImage,
+		Properties: []krb.Property{
+			{ID: krb.PropIDForegroundColor, Value: []byte{9}}, // palette index 9 = red
+		},
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Value: []byte{1}},
+		},
+	})
+	if !el.TintFromFg {
+		t.Fatalf("expected TintFromFg to be true")
+	}
+	if el.ImageTint != Palette[9] {
+		t.Fatalf("ImageTint = %+v, want %+v (FgColor)", el.ImageTint, Palette[9])
+	}
+}
+
+func TestResolvedImageTintMultipliesOpacityIntoAlpha(t *testing.T) {
+	el := &RenderElement{ImageTint: Color{R: 1, G: 2, B: 3, A: 200}, Opacity: 0.5}
+	got := ResolvedImageTint(el)
+	if got.R != 1 || got.G != 2 || got.B != 3 || got.A != 100 {
+		t.Fatalf("ResolvedImageTint = %+v, want alpha halved to 100", got)
+	}
+}
+
+func TestGrayscaleCustomProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "grayscale"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeImage,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Value: []byte{1}},
+		},
+	})
+	if !el.Grayscale {
+		t.Fatalf("expected Grayscale to be true")
+	}
+}
@@ -0,0 +1,140 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// includeKey is the conventional custom property marking a placeholder
+// element as a document-relative include, the same way _componentName
+// marks a component-instance placeholder (see component.go). Its value
+// is a string-table index holding a path, relative to whatever the host
+// document itself was loaded from.
+//
+// The spec also allows signaling an include via a RES_TYPE_Custom
+// resource entry; a custom property is used here instead so an include
+// placeholder looks and is processed exactly like a component-instance
+// placeholder, keeping one runtime-element-creation pattern in this
+// codebase rather than two.
+const includeKey = "krb_include"
+
+// maxIncludeDepth bounds how many includes deep ExpandIncludes will
+// follow before giving up, the same way a recursive-descent parser bounds
+// recursion -- a cycle (A includes B includes A) would otherwise recurse
+// forever even though no single include repeats a path.
+const maxIncludeDepth = 8
+
+// IncludeLoader loads the .krb document referenced by an include's path
+// and returns its decoded Document. This package never touches the
+// filesystem itself: resolving path relative to krbFileDir and enforcing
+// path-security rules (no escaping the project root, no absolute paths,
+// ...) is the caller's responsibility, since only the caller knows where
+// the host document came from and what's safe to read from disk.
+type IncludeLoader func(path string) (*krb.Document, error)
+
+// ExpandIncludes walks built looking for the conventional "krb_include"
+// custom property and replaces each such placeholder, in place among its
+// parent's children, with the root element of the fragment loader loads
+// for it -- recursively, so an included fragment's own includes are
+// expanded too, up to maxIncludeDepth and rejecting any cycle.
+//
+// A grafted fragment's elements keep pointing at the fragment's own
+// Document (each RenderElement already carries its own Doc field), so
+// string, style and resource indices never need remapping into the host
+// document's tables -- they're simply never looked up there. Event
+// handler names still resolve correctly because RegisterHandler's
+// registry is global across documents, not per-Document.
+//
+// PrepareTree and PrepareWindows don't call ExpandIncludes automatically,
+// since only the caller has an IncludeLoader to give it; call it after
+// them, before Layout.
+func ExpandIncludes(built []*RenderElement, loader IncludeLoader) error {
+	return expandIncludesIn(built, loader, nil)
+}
+
+func expandIncludesIn(elements []*RenderElement, loader IncludeLoader, chain []string) error {
+	for _, el := range elements {
+		if path, ok := includePath(el); ok {
+			if err := expandInclude(el, path, loader, chain); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expandIncludesIn(el.Children, loader, chain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandInclude(placeholder *RenderElement, path string, loader IncludeLoader, chain []string) error {
+	for _, p := range chain {
+		if p == path {
+			return &IncludeError{Path: path, Message: "cycle detected"}
+		}
+	}
+	if len(chain) >= maxIncludeDepth {
+		return &IncludeError{Path: path, Message: "exceeds max include depth"}
+	}
+
+	fragDoc, err := loader(path)
+	if err != nil {
+		return &IncludeError{Path: path, Message: err.Error()}
+	}
+
+	fragBuilt, hasParent := buildTree(fragDoc)
+	if err := expandComponents(fragDoc, fragBuilt); err != nil {
+		return &IncludeError{Path: path, Message: err.Error()}
+	}
+
+	nextChain := append(append([]string{}, chain...), path)
+	if err := expandIncludesIn(fragBuilt, loader, nextChain); err != nil {
+		return err
+	}
+
+	root := fragmentRoot(fragBuilt, hasParent)
+	if root == nil {
+		return &IncludeError{Path: path, Message: "fragment has no root element"}
+	}
+	graftInclude(placeholder, root)
+	return nil
+}
+
+func includePath(el *RenderElement) (string, bool) {
+	for _, cp := range el.Source.CustomProperties {
+		if el.Doc.String(cp.KeyIndex) == includeKey {
+			return customStringValue(el.Doc, cp), true
+		}
+	}
+	return "", false
+}
+
+func fragmentRoot(built []*RenderElement, hasParent []bool) *RenderElement {
+	for i, el := range built {
+		if !hasParent[i] {
+			return el
+		}
+	}
+	return nil
+}
+
+// graftInclude replaces placeholder among its parent's children with
+// root. A placeholder with no parent (itself a whole document's only
+// root) can't be replaced in place and is left untouched.
+func graftInclude(placeholder, root *RenderElement) {
+	parent := placeholder.Parent
+	if parent == nil {
+		return
+	}
+	idx := indexOfChild(parent, placeholder)
+	parent.InsertChildAt(root, idx)
+	parent.RemoveChild(placeholder)
+}
+
+// IncludeError describes why ExpandIncludes couldn't resolve one
+// "krb_include" placeholder.
+type IncludeError struct {
+	Path    string
+	Message string
+}
+
+func (e *IncludeError) Error() string {
+	return "render: krb_include " + e.Path + ": " + e.Message
+}
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func includeHostDoc(path string) *krb.Document {
+	doc := &krb.Document{Strings: []string{"", "krb_include", path}}
+	doc.Elements = []*krb.Element{
+		{Index: 0, Type: krb.ElemTypeContainer, Children: []int{1}},
+		{
+			Index: 1,
+			Type:  krb.ElemTypeContainer,
+			CustomProperties: []krb.CustomProperty{
+				{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+			},
+		},
+	}
+	return doc
+}
+
+func includeFragmentDoc() *krb.Document {
+	doc := &krb.Document{Strings: []string{"", "header-title"}}
+	doc.Elements = []*krb.Element{
+		{Index: 0, Type: krb.ElemTypeText, ID: 1},
+	}
+	return doc
+}
+
+func TestExpandIncludesGraftsFragmentRootInPlaceOfPlaceholder(t *testing.T) {
+	host := includeHostDoc("header.krb")
+	built, _ := buildTree(host)
+
+	loader := func(path string) (*krb.Document, error) {
+		if path != "header.krb" {
+			t.Fatalf("unexpected include path %q", path)
+		}
+		return includeFragmentDoc(), nil
+	}
+
+	if err := ExpandIncludes(built, loader); err != nil {
+		t.Fatalf("ExpandIncludes: %v", err)
+	}
+
+	root := built[0]
+	if len(root.Children) != 1 {
+		t.Fatalf("expected 1 child after grafting, got %d", len(root.Children))
+	}
+	if root.Children[0].Type != krb.ElemTypeText {
+		t.Fatalf("expected the fragment's text root to replace the placeholder, got type %v", root.Children[0].Type)
+	}
+	if root.Children[0].Parent != root {
+		t.Fatalf("expected grafted root's Parent to point back at the host container")
+	}
+}
+
+func TestExpandIncludesRejectsCycle(t *testing.T) {
+	host := includeHostDoc("self.krb")
+	built, _ := buildTree(host)
+
+	var loader IncludeLoader
+	loader = func(path string) (*krb.Document, error) {
+		frag := includeHostDoc("self.krb")
+		return frag, nil
+	}
+
+	err := ExpandIncludes(built, loader)
+	if err == nil {
+		t.Fatalf("expected a cycle error, got nil")
+	}
+	var incErr *IncludeError
+	if !errors.As(err, &incErr) {
+		t.Fatalf("expected *IncludeError, got %T", err)
+	}
+}
+
+func TestExpandIncludesPropagatesLoaderError(t *testing.T) {
+	host := includeHostDoc("missing.krb")
+	built, _ := buildTree(host)
+
+	loader := func(path string) (*krb.Document, error) {
+		return nil, errors.New("not found")
+	}
+
+	if err := ExpandIncludes(built, loader); err == nil {
+		t.Fatalf("expected loader error to propagate")
+	}
+}
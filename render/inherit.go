@@ -0,0 +1,53 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// applyInheritBackgroundCustomProperty reads the conventional
+// "inheritBackground" custom property into InheritBackground.
+func applyInheritBackgroundCustomProperty(re *RenderElement) {
+	re.InheritBackground = false
+	for _, cp := range re.Source.CustomProperties {
+		if re.Doc.String(cp.KeyIndex) == "inheritBackground" {
+			re.InheritBackground = len(cp.Value) > 0 && cp.Value[0] != 0
+		}
+	}
+}
+
+// resolvePropertyInheritance walks each of roots' subtrees, cascading a
+// background color down to Container descendants that don't set their
+// own (BgColor.A == 0, the same "unset" convention drawElement already
+// uses to skip drawing a background rect at all) wherever an ancestor
+// opted in via the "inheritBackground" custom property
+// (RenderElement.InheritBackground). Containers stay transparent by
+// default; nothing cascades unless some ancestor explicitly requests it.
+func resolvePropertyInheritance(roots []*RenderElement) {
+	for _, root := range roots {
+		propagateBackgroundInheritance(root, Color{}, false)
+	}
+}
+
+// propagateBackgroundInheritance carries inherited, the background a
+// Container without its own should default to, and active, whether any
+// ancestor has opted in yet, down through el's subtree. Once active,
+// inherited re-bases on every element that resolves a non-transparent
+// background of its own (inherited or explicit), so a nested container
+// with an explicit bg becomes the new default for its own descendants --
+// the cascade only needs to be switched on once per subtree, at whatever
+// element declares InheritBackground.
+func propagateBackgroundInheritance(el *RenderElement, inherited Color, active bool) {
+	if el.InheritBackground {
+		active = true
+		inherited = el.BgColor
+	}
+	if active {
+		if el.Type == krb.ElemTypeContainer && el.BgColor.A == 0 {
+			el.BgColor = inherited
+		}
+		if el.BgColor.A > 0 {
+			inherited = el.BgColor
+		}
+	}
+	for _, c := range el.Children {
+		propagateBackgroundInheritance(c, inherited, active)
+	}
+}
@@ -0,0 +1,79 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func newInheritTestContainer(doc *krb.Document) *RenderElement {
+	return newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+}
+
+func TestResolvePropertyInheritanceCascadesOptedInBackground(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newInheritTestContainer(doc)
+	parent.BgColor = Color{R: 10, G: 20, B: 30, A: 255}
+	parent.InheritBackground = true
+	child := newInheritTestContainer(doc)
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	resolvePropertyInheritance([]*RenderElement{parent})
+
+	if child.BgColor != parent.BgColor {
+		t.Fatalf("child.BgColor = %v, want inherited %v", child.BgColor, parent.BgColor)
+	}
+}
+
+func TestResolvePropertyInheritanceLeavesBackgroundTransparentWithoutOptIn(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newInheritTestContainer(doc)
+	parent.BgColor = Color{R: 10, G: 20, B: 30, A: 255}
+	child := newInheritTestContainer(doc)
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	resolvePropertyInheritance([]*RenderElement{parent})
+
+	if child.BgColor != (Color{}) {
+		t.Fatalf("child.BgColor = %v, want transparent (no inheritance requested)", child.BgColor)
+	}
+}
+
+func TestResolvePropertyInheritanceDoesNotOverrideChildsOwnBackground(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newInheritTestContainer(doc)
+	parent.BgColor = Color{R: 10, G: 20, B: 30, A: 255}
+	parent.InheritBackground = true
+	child := newInheritTestContainer(doc)
+	child.BgColor = Color{R: 99, G: 99, B: 99, A: 255}
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	resolvePropertyInheritance([]*RenderElement{parent})
+
+	want := Color{R: 99, G: 99, B: 99, A: 255}
+	if child.BgColor != want {
+		t.Fatalf("child.BgColor = %v, want its own %v preserved", child.BgColor, want)
+	}
+}
+
+func TestResolvePropertyInheritanceCascadesThroughMultipleLevels(t *testing.T) {
+	doc := &krb.Document{}
+	grandparent := newInheritTestContainer(doc)
+	grandparent.BgColor = Color{R: 1, G: 2, B: 3, A: 255}
+	grandparent.InheritBackground = true
+	parent := newInheritTestContainer(doc)
+	parent.Parent = grandparent
+	grandparent.Children = []*RenderElement{parent}
+	child := newInheritTestContainer(doc)
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	resolvePropertyInheritance([]*RenderElement{grandparent})
+
+	if parent.BgColor != grandparent.BgColor || child.BgColor != grandparent.BgColor {
+		t.Fatalf("expected background to cascade to both descendants, got parent=%v child=%v", parent.BgColor, child.BgColor)
+	}
+}
@@ -0,0 +1,180 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// InputState is one frame's sampled input, independent of any backend.
+// It's what makes event dispatch (hit-testing, click/hover/focus state
+// machines) pure and unit-testable with synthetic press/move/release
+// sequences, and what a render-to-texture embedding can build by hand
+// (e.g. with coordinates already transformed into its own screen space)
+// to feed InputDispatcher.Dispatch instead of a backend's default
+// sampler (see raylib.Renderer.InjectInput).
+type InputState struct {
+	MouseX, MouseY float32
+	MouseDown      [3]bool // indexed by MouseButton
+	Wheel          float32 // scrolls the deepest krb.ElemTypeScrollable under the cursor; see dispatchWheel
+	KeysDown       map[string]bool
+
+	Shift, Ctrl, Alt, Meta bool
+}
+
+// InputDispatcher turns a sequence of InputState snapshots into
+// dispatched events by diffing each one against the last: a mouse button
+// going down then up while still over the element it went down on is a
+// click, the hit-tested element changing is a hover transition, and a
+// newly pressed interactive element becomes focused (blurring whatever
+// was focused before). Keep one InputDispatcher per window -- it carries
+// state between calls, so a fresh one has no "previous frame" to diff
+// against yet.
+type InputDispatcher struct {
+	prev    InputState
+	hasPrev bool
+
+	Hovered *RenderElement
+	Focused *RenderElement
+
+	// HoverElapsed is how long Hovered has been continuously hovered,
+	// in seconds; see AdvanceHover and TooltipTarget.
+	HoverElapsed float32
+
+	// MinHitWidth and MinHitHeight are the minimum hit-testing rectangle
+	// size applied to every IsInteractive element, set via
+	// SetMinimumHitTarget. Zero (the default) applies no minimum.
+	MinHitWidth, MinHitHeight float32
+
+	pressed [3]*RenderElement
+}
+
+// SetMinimumHitTarget sets the minimum size of an IsInteractive
+// element's hit-testing rectangle to w x h, padding it out (centered on
+// the element's unchanged visual rect) when its own size falls short.
+// This never affects drawing or layout -- only which element a given
+// point in Dispatch resolves to. It's meant for touch hardware where a
+// button drawn smaller than a fingertip is otherwise nearly impossible
+// to hit reliably; see the per-element "hitPadding" custom property
+// (RenderElement.HitPadding) for explicit expansion on top of this.
+func (d *InputDispatcher) SetMinimumHitTarget(w, h float32) {
+	d.MinHitWidth, d.MinHitHeight = w, h
+}
+
+// Dispatch hit-tests state against roots and fires whatever
+// press/release/click/hover/focus/blur/key events the transition from
+// the previous Dispatch call implies. state.Wheel scrolls the innermost
+// krb.ElemTypeScrollable containing the hit-tested point, and focusing an
+// element inside a scrollable calls ScrollIntoView so it's never focused
+// offscreen. Every press/release/click also reaches
+// dispatchComponentEvent, which additionally notifies whatever
+// ComponentEventHandler is registered for the hit element's component.
+func (d *InputDispatcher) Dispatch(roots []*RenderElement, state InputState) {
+	hit := d.hitTestRoots(roots, state.MouseX, state.MouseY)
+	d.dispatchHover(hit, state)
+	d.dispatchWheel(hit, state)
+	d.dispatchButtons(hit, state)
+	d.dispatchKeys(state)
+	d.prev = state
+	d.hasPrev = true
+}
+
+// hitTestRoots resolves (x, y) to an element the same way HitTest does,
+// falling back to nearestExpandedHit -- using d's configured
+// SetMinimumHitTarget size and each candidate's own HitPadding -- only
+// when no element's actual visual rect contains the point. An exact hit
+// always wins over an expanded one.
+func (d *InputDispatcher) hitTestRoots(roots []*RenderElement, x, y float32) *RenderElement {
+	for _, root := range roots {
+		if hit := HitTest(root, x, y); hit != nil {
+			return hit
+		}
+	}
+	return nearestExpandedHit(roots, x, y, d.MinHitWidth, d.MinHitHeight)
+}
+
+func (d *InputDispatcher) dispatchHover(hit *RenderElement, state InputState) {
+	if hit == d.Hovered {
+		return
+	}
+	d.Hovered = hit
+	d.HoverElapsed = 0
+	if hit != nil {
+		Dispatch(hit, krb.EventTypeHover, state.MouseX, state.MouseY)
+	}
+}
+
+// AdvanceHover accumulates dt into HoverElapsed while an element stays
+// continuously hovered, for TooltipTarget's delay check. Call it once
+// per frame with the real frame delta, the same way AdvanceAnimations
+// drives sprite animation -- not from Dispatch itself, since a
+// backend's frame rate (not its input sampling rate) is what the delay
+// should be measured against.
+func (d *InputDispatcher) AdvanceHover(dt float32) {
+	if d.Hovered == nil {
+		d.HoverElapsed = 0
+		return
+	}
+	d.HoverElapsed += dt
+}
+
+func (d *InputDispatcher) dispatchButtons(hit *RenderElement, state InputState) {
+	for i := 0; i < len(state.MouseDown); i++ {
+		button := MouseButton(i)
+		wasDown := d.hasPrev && d.prev.MouseDown[i]
+		isDown := state.MouseDown[i]
+
+		switch {
+		case isDown && !wasDown:
+			d.pressed[i] = hit
+			if hit != nil {
+				DispatchButton(hit, krb.EventTypePress, state.MouseX, state.MouseY, button)
+				dispatchComponentEvent(hit, krb.EventTypePress, state, button)
+			}
+			d.focus(hit)
+		case !isDown && wasDown:
+			target := d.pressed[i]
+			d.pressed[i] = nil
+			if target != nil {
+				DispatchButton(target, krb.EventTypeRelease, state.MouseX, state.MouseY, button)
+				dispatchComponentEvent(target, krb.EventTypeRelease, state, button)
+			}
+			if hit != nil && hit == target {
+				clickType := EventTypeForButton(button)
+				DispatchButton(hit, clickType, state.MouseX, state.MouseY, button)
+				dispatchComponentEvent(hit, clickType, state, button)
+			}
+		}
+	}
+}
+
+func (d *InputDispatcher) focus(candidate *RenderElement) {
+	if candidate == nil || !candidate.IsInteractive || candidate == d.Focused {
+		return
+	}
+	if d.Focused != nil {
+		Dispatch(d.Focused, krb.EventTypeBlur, 0, 0)
+	}
+	d.Focused = candidate
+	Dispatch(candidate, krb.EventTypeFocus, 0, 0)
+	ScrollIntoView(candidate)
+}
+
+func (d *InputDispatcher) dispatchKeys(state InputState) {
+	mods := KeyEvent{Shift: state.Shift, Ctrl: state.Ctrl, Alt: state.Alt, Meta: state.Meta}
+	for key, down := range state.KeysDown {
+		if !down || (d.hasPrev && d.prev.KeysDown[key]) {
+			continue
+		}
+		evt := mods
+		evt.Key = key
+		DispatchKey(evt)
+	}
+}
+
+// customStringValue decodes a custom property's Value as a string-table
+// reference, the same way PropIDTextContent decodes a standard property:
+// Value's first byte is a string table index. Returns "" for a custom
+// property that isn't a string (e.g. it was declared as a bare flag).
+func customStringValue(doc *krb.Document, cp krb.CustomProperty) string {
+	if cp.Type != krb.ValTypeString || len(cp.Value) == 0 {
+		return ""
+	}
+	return doc.String(cp.Value[0])
+}
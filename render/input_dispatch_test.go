@@ -0,0 +1,159 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func newInputDispatchTestButton(doc *krb.Document, x, y, w, h float32) *RenderElement {
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeButton})
+	el.X, el.Y, el.Width, el.Height = x, y, w, h
+	return el
+}
+
+func TestInputDispatcherFiresClickOnPressThenReleaseOverSameElement(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"dispatcher-click-test"}}
+	btn := newInputDispatchTestButton(doc, 0, 0, 100, 50)
+
+	var clicked int
+	RegisterHandler("dispatcher-click-test", func(*Event) { clicked++ })
+	btn.Source.Events = []krb.Event{{Type: krb.EventTypeClick, CallbackID: 0}}
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: 10, MouseY: 10})
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: 10, MouseY: 10, MouseDown: [3]bool{true}})
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: 10, MouseY: 10})
+
+	if clicked != 1 {
+		t.Fatalf("clicked = %d, want 1", clicked)
+	}
+}
+
+func TestInputDispatcherDoesNotClickWhenReleasedOffElement(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"dispatcher-no-click-test"}}
+	btn := newInputDispatchTestButton(doc, 0, 0, 100, 50)
+
+	var clicked int
+	RegisterHandler("dispatcher-no-click-test", func(*Event) { clicked++ })
+	btn.Source.Events = []krb.Event{{Type: krb.EventTypeClick, CallbackID: 0}}
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: 10, MouseY: 10, MouseDown: [3]bool{true}})
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: 500, MouseY: 500})
+
+	if clicked != 0 {
+		t.Fatalf("clicked = %d, want 0 (released off the pressed element)", clicked)
+	}
+}
+
+func TestInputDispatcherTracksHoverTransitions(t *testing.T) {
+	doc := &krb.Document{}
+	a := newInputDispatchTestButton(doc, 0, 0, 50, 50)
+	b := newInputDispatchTestButton(doc, 100, 0, 50, 50)
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{a, b}, InputState{MouseX: 10, MouseY: 10})
+	if d.Hovered != a {
+		t.Fatalf("expected a to be hovered")
+	}
+	d.Dispatch([]*RenderElement{a, b}, InputState{MouseX: 110, MouseY: 10})
+	if d.Hovered != b {
+		t.Fatalf("expected b to be hovered after moving over it")
+	}
+}
+
+func TestInputDispatcherFocusesPressedInteractiveElementAndBlursPrevious(t *testing.T) {
+	doc := &krb.Document{}
+	a := newInputDispatchTestButton(doc, 0, 0, 50, 50)
+	b := newInputDispatchTestButton(doc, 100, 0, 50, 50)
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{a, b}, InputState{MouseX: 10, MouseY: 10, MouseDown: [3]bool{true}})
+	if d.Focused != a {
+		t.Fatalf("expected a focused after press")
+	}
+	d.Dispatch([]*RenderElement{a, b}, InputState{MouseX: 10, MouseY: 10})
+	d.Dispatch([]*RenderElement{a, b}, InputState{MouseX: 110, MouseY: 10, MouseDown: [3]bool{true}})
+	if d.Focused != b {
+		t.Fatalf("expected b focused after pressing it")
+	}
+}
+
+func TestInputDispatcherExpandsHitTargetToMinimumSize(t *testing.T) {
+	doc := &krb.Document{}
+	btn := newInputDispatchTestButton(doc, 0, 0, 10, 10)
+
+	var d InputDispatcher
+	d.SetMinimumHitTarget(40, 40)
+	// (18, 18) misses the 10x10 visual rect but falls inside the 40x40
+	// minimum target centered on it.
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: 18, MouseY: 18})
+	if d.Hovered != btn {
+		t.Fatalf("expected btn hovered via expanded minimum hit target")
+	}
+}
+
+func TestInputDispatcherExpandsHitTargetViaHitPadding(t *testing.T) {
+	doc := &krb.Document{}
+	btn := newInputDispatchTestButton(doc, 0, 0, 10, 10)
+	btn.HitPadding = 20
+
+	var d InputDispatcher
+	d.Dispatch([]*RenderElement{btn}, InputState{MouseX: -15, MouseY: 5})
+	if d.Hovered != btn {
+		t.Fatalf("expected btn hovered via HitPadding expansion")
+	}
+}
+
+func TestInputDispatcherNeverExpandsNonInteractiveElement(t *testing.T) {
+	doc := &krb.Document{}
+	container := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	container.X, container.Y, container.Width, container.Height = 0, 0, 10, 10
+	container.HitPadding = 20
+
+	var d InputDispatcher
+	d.SetMinimumHitTarget(40, 40)
+	d.Dispatch([]*RenderElement{container}, InputState{MouseX: 18, MouseY: 18})
+	if d.Hovered != nil {
+		t.Fatalf("non-interactive element must never gain an expanded hit target")
+	}
+}
+
+func TestInputDispatcherExpandedHitTargetTieBreaksByNearestVisualRect(t *testing.T) {
+	doc := &krb.Document{}
+	// Two small buttons 20px apart, each padded out far enough that
+	// their expanded targets overlap between them.
+	near := newInputDispatchTestButton(doc, 0, 0, 10, 10)
+	far := newInputDispatchTestButton(doc, 30, 0, 10, 10)
+	near.HitPadding = 20
+	far.HitPadding = 20
+
+	var d InputDispatcher
+	// x=14 is 4px past near's visual rect and 16px short of far's --
+	// nearer to near, so near should win the overlap.
+	d.Dispatch([]*RenderElement{near, far}, InputState{MouseX: 14, MouseY: 5})
+	if d.Hovered != near {
+		t.Fatalf("expected near to win the overlapping expanded target, got %v", d.Hovered)
+	}
+
+	// x=26 is nearer to far.
+	d.Dispatch([]*RenderElement{near, far}, InputState{MouseX: 26, MouseY: 5})
+	if d.Hovered != far {
+		t.Fatalf("expected far to win the overlapping expanded target, got %v", d.Hovered)
+	}
+}
+
+func TestInputDispatcherRoutesKeyPressEdgeToShortcut(t *testing.T) {
+	var fired int
+	RegisterShortcut("ctrl+k", func(KeyEvent) { fired++ })
+
+	var d InputDispatcher
+	d.Dispatch(nil, InputState{KeysDown: map[string]bool{"k": true}, Ctrl: true})
+	d.Dispatch(nil, InputState{KeysDown: map[string]bool{"k": true}, Ctrl: true})
+	d.Dispatch(nil, InputState{})
+
+	if fired != 1 {
+		t.Fatalf("fired = %d, want 1 (only on the press edge)", fired)
+	}
+}
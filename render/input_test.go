@@ -0,0 +1,31 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestPasswordInputMasksText(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "input_type", "password"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeInput,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	})
+	el.TextContent = "hunter2"
+
+	if !el.Masked {
+		t.Fatalf("expected input_type=password to set Masked")
+	}
+	if got := el.MaskedText(); got != "*******" {
+		t.Fatalf("MaskedText() = %q, want 7 asterisks", got)
+	}
+}
+
+func TestFilterNumericInput(t *testing.T) {
+	if got := FilterNumericInput("-12a.3.4b"); got != "-12.34" {
+		t.Fatalf("FilterNumericInput() = %q", got)
+	}
+}
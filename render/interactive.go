@@ -0,0 +1,89 @@
+package render
+
+import "strings"
+
+// Input-specific behavior is configured via the conventional "input_type"
+// custom property on an ElemTypeInput element (see krb_source_spec.md's
+// Custom Properties section), since these are app-level UX choices rather
+// than values the compiler can express as standard properties.
+const (
+	inputTypePassword = "password"
+	inputTypeNumeric  = "numeric"
+)
+
+// Placeholder and Disabled are read from the conventional custom property
+// keys "placeholder" and "disabled" on interactive elements (Input,
+// Button). Disabled-ness driven by interaction state (STATE_DISABLED in a
+// State Property Set) is layered on top by the state-resolution pass once
+// it exists; this is the element's own static, KRB-declared default.
+func applyInteractiveCustomProperties(re *RenderElement) {
+	re.Placeholder = ""
+	re.Disabled = false
+	for _, cp := range re.Source.CustomProperties {
+		switch re.Doc.String(cp.KeyIndex) {
+		case "placeholder":
+			re.Placeholder = customStringValue(re.Doc, cp)
+		case "disabled":
+			re.Disabled = len(cp.Value) > 0 && cp.Value[0] != 0
+		}
+	}
+}
+
+// applyCustomProperties reads an element's own Custom Properties (as
+// opposed to standard properties, which come from applyProperties).
+// Unlike standard properties these are never inherited from a style.
+func applyCustomProperties(re *RenderElement) {
+	re.Masked = false
+	re.NumericOnly = false
+	for _, cp := range re.Source.CustomProperties {
+		key := re.Doc.String(cp.KeyIndex)
+		switch key {
+		case "input_type":
+			switch customStringValue(re.Doc, cp) {
+			case inputTypePassword:
+				re.Masked = true
+			case inputTypeNumeric:
+				re.NumericOnly = true
+			}
+		}
+	}
+}
+
+// MaskedText returns el's text content with every rune replaced by '*'
+// when el is a password-masked input; otherwise it returns TextContent
+// unchanged.
+func (el *RenderElement) MaskedText() string {
+	if !el.Masked {
+		return el.TextContent
+	}
+	return strings.Repeat("*", len([]rune(el.TextContent)))
+}
+
+// FilterNumericInput strips every non-digit rune (other than a single
+// leading '-' and one '.') from s, for use by backends that need to
+// enforce a numeric-only Input element as the user types.
+func FilterNumericInput(s string) string {
+	var b strings.Builder
+	seenDot := false
+	for i, r := range s {
+		switch {
+		case r == '-' && i == 0:
+			b.WriteRune(r)
+		case r == '.' && !seenDot:
+			seenDot = true
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DisplayText returns what an Input element should show: its typed
+// content, or its Placeholder when empty.
+func (el *RenderElement) DisplayText() string {
+	if el.TextContent != "" {
+		return el.MaskedText()
+	}
+	return el.Placeholder
+}
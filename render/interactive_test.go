@@ -0,0 +1,30 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestDisplayTextFallsBackToPlaceholder(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "placeholder", "Email address", "disabled"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeInput,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+			{KeyIndex: 3, Value: []byte{1}},
+		},
+	})
+
+	if got := el.DisplayText(); got != "Email address" {
+		t.Fatalf("DisplayText() = %q, want placeholder", got)
+	}
+	if !el.Disabled {
+		t.Fatalf("expected Disabled to be true")
+	}
+
+	el.TextContent = "me@example.com"
+	if got := el.DisplayText(); got != "me@example.com" {
+		t.Fatalf("DisplayText() = %q, want typed content once non-empty", got)
+	}
+}
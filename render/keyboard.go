@@ -0,0 +1,91 @@
+package render
+
+import "strings"
+
+// KeyEvent describes one physical key transition reported by a backend's
+// input loop. Key is the backend-independent key name (e.g. "s", "enter",
+// "f1"); backends are responsible for translating their own key constants
+// into these names. Repeat is set for the synthetic repeated presses a
+// backend generates while a key is held down (e.g. for text-entry cursor
+// movement); shortcuts don't fire on repeats unless registered with
+// RegisterRepeatingShortcut.
+type KeyEvent struct {
+	Key                    string
+	Shift, Ctrl, Alt, Meta bool
+	Repeat                 bool
+}
+
+type shortcut struct {
+	fn      func(KeyEvent)
+	repeats bool
+}
+
+var shortcuts = map[string]shortcut{}
+
+// RegisterShortcut binds combo (e.g. "ctrl+s", "ctrl+shift+z") to fn. The
+// combo is matched against a KeyEvent regardless of letter case, with
+// modifier names and the key itself joined by "+" in any order. Like
+// RegisterHandler, re-registering a combo replaces its handler. The
+// shortcut does not fire for repeated key-down events generated while the
+// key is held; use RegisterRepeatingShortcut for those (e.g. an
+// undo/redo-style action some apps want to auto-repeat).
+func RegisterShortcut(combo string, fn func(KeyEvent)) {
+	shortcuts[normalizeCombo(combo)] = shortcut{fn: fn}
+}
+
+// RegisterRepeatingShortcut is RegisterShortcut, except fn also fires for
+// the synthetic repeat events a backend generates while combo's key is
+// held down.
+func RegisterRepeatingShortcut(combo string, fn func(KeyEvent)) {
+	shortcuts[normalizeCombo(combo)] = shortcut{fn: fn, repeats: true}
+}
+
+// DispatchKey routes evt to its registered shortcut, if any, and reports
+// whether one fired. A repeat event only reaches shortcuts registered via
+// RegisterRepeatingShortcut.
+func DispatchKey(evt KeyEvent) bool {
+	sc, ok := shortcuts[comboKey(evt)]
+	if !ok || (evt.Repeat && !sc.repeats) {
+		return false
+	}
+	sc.fn(evt)
+	return true
+}
+
+// modifierOrder is the fixed order both normalizeCombo and comboKey build
+// their strings in, so a combo registered with its modifiers in any order
+// (e.g. "shift+ctrl+s") still matches the ctrl/alt/shift/meta/key order
+// comboKey derives from a KeyEvent.
+var modifierOrder = []string{"ctrl", "alt", "shift", "meta"}
+
+func normalizeCombo(combo string) string {
+	var ctrl, alt, shift, meta bool
+	var key string
+	for _, p := range strings.Split(combo, "+") {
+		switch p := strings.ToLower(strings.TrimSpace(p)); p {
+		case "ctrl":
+			ctrl = true
+		case "alt":
+			alt = true
+		case "shift":
+			shift = true
+		case "meta":
+			meta = true
+		default:
+			key = p
+		}
+	}
+	return comboKey(KeyEvent{Key: key, Shift: shift, Ctrl: ctrl, Alt: alt, Meta: meta})
+}
+
+func comboKey(evt KeyEvent) string {
+	mods := map[string]bool{"ctrl": evt.Ctrl, "alt": evt.Alt, "shift": evt.Shift, "meta": evt.Meta}
+	var parts []string
+	for _, m := range modifierOrder {
+		if mods[m] {
+			parts = append(parts, m)
+		}
+	}
+	parts = append(parts, strings.ToLower(evt.Key))
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,35 @@
+package render
+
+import "testing"
+
+func TestDispatchKeyFiresMatchingShortcutRegardlessOfModifierOrder(t *testing.T) {
+	fired := false
+	RegisterShortcut("Shift+Ctrl+S", func(KeyEvent) { fired = true })
+
+	if !DispatchKey(KeyEvent{Key: "s", Ctrl: true, Shift: true}) {
+		t.Fatalf("DispatchKey returned false for a registered combo")
+	}
+	if !fired {
+		t.Fatalf("expected shortcut handler to run")
+	}
+}
+
+func TestDispatchKeyIgnoresRepeatsUnlessRegisteredAsRepeating(t *testing.T) {
+	calls := 0
+	RegisterShortcut("ctrl+n", func(KeyEvent) { calls++ })
+
+	if DispatchKey(KeyEvent{Key: "n", Ctrl: true, Repeat: true}) {
+		t.Fatalf("expected a repeat event not to fire a non-repeating shortcut")
+	}
+	if calls != 0 {
+		t.Fatalf("calls = %d, want 0", calls)
+	}
+
+	repeatCalls := 0
+	RegisterRepeatingShortcut("ctrl+plus", func(KeyEvent) { repeatCalls++ })
+	DispatchKey(KeyEvent{Key: "plus", Ctrl: true})
+	DispatchKey(KeyEvent{Key: "plus", Ctrl: true, Repeat: true})
+	if repeatCalls != 2 {
+		t.Fatalf("repeatCalls = %d, want 2", repeatCalls)
+	}
+}
@@ -0,0 +1,487 @@
+package render
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// Layout, like the rest of this file, touches only RenderElement fields
+// and krb constants -- no raylib or other backend-specific type appears
+// anywhere in the layout pass. A backend only needs render.PrepareTree
+// (or PrepareWindows) followed by render.Layout to get a fully positioned
+// tree it can walk and draw in whatever way fits its graphics API; see
+// raylib.Renderer.Draw for the reference consumer.
+//
+// Layout positions every element in the given trees. Roots are assumed to
+// already have their final Width/Height (from the App's window config or
+// an explicit KRB size); everything below a root is arranged relative to
+// it according to its Layout byte (direction, alignment, wrap, grow).
+//
+// Layout always produces a best-effort, panic-free result even when the
+// KRB file asks for something impossible (e.g. fixed-size children that
+// together don't fit their container); the returned errors describe each
+// constraint it had to clamp rather than satisfy exactly.
+//
+// Before arranging each root, Layout resolves that root's breakpoints
+// (see resolveBreakpoints) against viewportWidth, so a window resize
+// that crosses a declared breakpoint takes effect on the very next call
+// with no separate step required from the caller.
+func Layout(roots []*RenderElement, viewportWidth, viewportHeight float32) []error {
+	var errs []error
+	for _, root := range roots {
+		if root.Width == 0 {
+			root.Width = viewportWidth
+		}
+		if root.Height == 0 {
+			root.Height = viewportHeight
+		}
+		resolveBreakpoints(root, root.breakpoints, viewportWidth)
+		layoutElement(root, &errs)
+	}
+	for _, root := range roots {
+		snapToPixels(root)
+	}
+	computeEffectiveVisibility(roots)
+	sanitizeNonFinite(roots, &errs)
+	if activeDamage != nil {
+		activeDamage.resolvePending()
+	}
+	return errs
+}
+
+// sanitizeNonFinite walks roots and replaces any X/Y/Width/Height that
+// resolved to NaN or +/-Inf with 0, recording a LayoutError naming the
+// element it found one on. Ordinary inputs never produce a non-finite
+// value through this file's arithmetic, but a bad combination elsewhere
+// (e.g. a grow share computed from a zero child count) has historically
+// reached here as NaN and propagated straight into a backend's draw
+// calls; this is the last point before Layout hands the tree back where
+// that's still cheap to catch.
+func sanitizeNonFinite(roots []*RenderElement, errs *[]error) {
+	for _, root := range roots {
+		sanitizeElementNonFinite(root, errs)
+	}
+}
+
+func sanitizeElementNonFinite(el *RenderElement, errs *[]error) {
+	sanitizeField(&el.X, "X", el, errs)
+	sanitizeField(&el.Y, "Y", el, errs)
+	sanitizeField(&el.Width, "Width", el, errs)
+	sanitizeField(&el.Height, "Height", el, errs)
+	for _, c := range el.Children {
+		sanitizeElementNonFinite(c, errs)
+	}
+}
+
+func sanitizeField(v *float32, name string, el *RenderElement, errs *[]error) {
+	f := float64(*v)
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return
+	}
+	*errs = append(*errs, &LayoutError{Element: el, Message: fmt.Sprintf(
+		"%s resolved to a non-finite value (%v); replaced with 0", name, *v)})
+	*v = 0
+}
+
+// computeEffectiveVisibility sets EffectiveVisible on every element in
+// roots by ANDing its own IsVisible down the Parent chain. It's a plain
+// top-down walk, not gated by the Dirty/cache checks layoutElement uses
+// for flow arrangement, since a visibility change doesn't necessarily
+// mark anything Dirty and the walk itself is cheap.
+func computeEffectiveVisibility(roots []*RenderElement) {
+	for _, root := range roots {
+		propagateEffectiveVisibility(root, true)
+	}
+}
+
+func propagateEffectiveVisibility(el *RenderElement, parentVisible bool) {
+	el.EffectiveVisible = parentVisible && el.IsVisible
+	for _, c := range el.Children {
+		propagateEffectiveVisibility(c, el.EffectiveVisible)
+	}
+}
+
+func layoutElement(el *RenderElement, errs *[]error) {
+	el.Padding = resolvePaddingDims(el.PaddingDim, el.Width)
+	el.Gap = el.GapDim.Resolve(mainAxisSize(el))
+	if el.FitContent {
+		fitContentSize(el)
+	}
+	if el.Width-el.Padding[1]-el.Padding[3] < 0 || el.Height-el.Padding[0]-el.Padding[2] < 0 {
+		*errs = append(*errs, &LayoutError{Element: el, Message: "padding exceeds the element's own size"})
+	}
+
+	sizeChanged := el.Width != el.cachedWidth || el.Height != el.cachedHeight
+	if el.laidOut && !el.Dirty && !sizeChanged {
+		// Neither el's own size nor anything MarkDirty cares about
+		// changed since the last pass, so its subtree's relative
+		// arrangement is still valid -- it just needs shifting by
+		// however far el itself moved (e.g. because an earlier sibling
+		// resized), without redoing any flow math.
+		dx, dy := el.X-el.cachedX, el.Y-el.cachedY
+		if el.Type == krb.ElemTypeScrollable {
+			// A caller can change ScrollOffsetX/Y directly (e.g. jumping
+			// to the top/bottom of a list) without anything else about
+			// el becoming Dirty, so the clamp normally done by
+			// layoutFlowChildren has to be re-applied here too, and the
+			// resulting shift folded in alongside el's own movement.
+			content := contentRectFrom(Rect{X: el.X, Y: el.Y, Width: el.Width, Height: el.Height}, el.Padding)
+			el.ScrollOffsetX = clampFloat32(el.ScrollOffsetX, 0, el.ContentWidth-content.Width)
+			el.ScrollOffsetY = clampFloat32(el.ScrollOffsetY, 0, el.ContentHeight-content.Height)
+			dx += el.appliedScrollOffsetX - el.ScrollOffsetX
+			dy += el.appliedScrollOffsetY - el.ScrollOffsetY
+			el.appliedScrollOffsetX, el.appliedScrollOffsetY = el.ScrollOffsetX, el.ScrollOffsetY
+		}
+		shiftSubtree(el, dx, dy)
+		el.cachedX, el.cachedY = el.X, el.Y
+		return
+	}
+
+	if len(el.Children) > 0 {
+		layoutFlowChildren(el, errs)
+	}
+	for _, c := range el.Children {
+		layoutElement(c, errs)
+	}
+	el.cachedWidth, el.cachedHeight = el.Width, el.Height
+	el.cachedX, el.cachedY = el.X, el.Y
+	el.laidOut = true
+	el.Dirty = false
+}
+
+// shiftSubtree translates every descendant of el (not el itself, whose
+// X/Y the parent already assigned) by (dx, dy). It's how layoutElement
+// reuses a cached subtree arrangement when el moved but didn't resize.
+func shiftSubtree(el *RenderElement, dx, dy float32) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	for _, c := range el.Children {
+		c.X += dx
+		c.Y += dy
+		shiftSubtree(c, dx, dy)
+	}
+}
+
+// layoutFlowChildren arranges el's children along the row/column main
+// axis described by el.EffectiveLayout, sharing any leftover space among
+// children whose Grow bit is set.
+func layoutFlowChildren(el *RenderElement, errs *[]error) {
+	layout := el.EffectiveLayout
+	column := layout&krb.LayoutDirectionMask == krb.LayoutDirectionColumn ||
+		layout&krb.LayoutDirectionMask == krb.LayoutDirectionColRev
+	reverse := layout&krb.LayoutDirectionMask == krb.LayoutDirectionRowRev ||
+		layout&krb.LayoutDirectionMask == krb.LayoutDirectionColRev
+
+	content := contentRectFrom(Rect{X: el.X, Y: el.Y, Width: el.Width, Height: el.Height}, el.Padding)
+	contentX, contentY, contentW, contentH := content.X, content.Y, content.Width, content.Height
+
+	mainSize := contentW
+	if column {
+		mainSize = contentH
+	}
+
+	// Reuse el's own scratch slices across calls instead of allocating a
+	// fresh pair every layout pass -- el.Children rarely changes size
+	// frame to frame, so after the backing arrays grow to fit once, this
+	// is steady-state allocation-free.
+	children := el.flowChildren[:0]
+	absolute := el.flowAbsolute[:0]
+	for _, c := range el.Children {
+		if c.EffectiveLayout&krb.LayoutAbsoluteBit != 0 {
+			absolute = append(absolute, c)
+		} else {
+			children = append(children, c)
+		}
+	}
+	el.flowChildren, el.flowAbsolute = children, absolute
+	for _, c := range absolute {
+		// An absolutely positioned child sits out of flow entirely and
+		// never affects siblings' main-axis sizing. Its declared PosX/PosY
+		// are an offset from the parent's content origin unless it names
+		// an Anchor, in which case OffsetX/OffsetY are measured from the
+		// corner (or center) the anchor names instead.
+		if c.Anchor == "" {
+			c.X = contentX + float32(c.Source.PosX)
+			c.Y = contentY + float32(c.Source.PosY)
+			continue
+		}
+		switch c.Anchor {
+		case AnchorTopRight:
+			c.X = contentX + contentW - c.Width - c.OffsetX
+			c.Y = contentY + c.OffsetY
+		case AnchorBottomLeft:
+			c.X = contentX + c.OffsetX
+			c.Y = contentY + contentH - c.Height - c.OffsetY
+		case AnchorBottomRight:
+			c.X = contentX + contentW - c.Width - c.OffsetX
+			c.Y = contentY + contentH - c.Height - c.OffsetY
+		case AnchorCenter:
+			c.X = contentX + (contentW-c.Width)/2 + c.OffsetX
+			c.Y = contentY + (contentH-c.Height)/2 + c.OffsetY
+		default: // AnchorTopLeft or an unrecognized value
+			c.X = contentX + c.OffsetX
+			c.Y = contentY + c.OffsetY
+		}
+	}
+
+	gapTotal := el.Gap * float32(maxInt(len(children)-1, 0))
+	fixedTotal := gapTotal
+	var growFactorTotal float32
+	for _, c := range children {
+		if c.EffectiveLayout&krb.LayoutGrowBit != 0 {
+			growFactorTotal += EffectiveGrowFactor(c)
+			fixedTotal += c.FlexBasisDim.Resolve(mainSize)
+			continue
+		}
+		if column {
+			fixedTotal += c.Height
+		} else {
+			fixedTotal += c.Width
+		}
+	}
+	// resolveGrowLens writes each grow child's resolved main-axis share
+	// straight into its Width/Height, respecting minimums along the way;
+	// the loop below then just reads it back, same as it reads a
+	// non-grow child's already-fixed size.
+	resolveGrowLens(children, column, mainSize, fixedTotal, growFactorTotal)
+	if growFactorTotal <= 0 && fixedTotal > mainSize {
+		*errs = append(*errs, &LayoutError{Element: el, Message: fmt.Sprintf(
+			"fixed-size children (%.1fpx incl. gaps) exceed the container's content size (%.1fpx)", fixedTotal, mainSize)})
+	}
+
+	cursor := float32(0)
+	ordered := children
+	if reverse {
+		ordered = el.flowOrdered[:0]
+		for len(ordered) < len(children) {
+			ordered = append(ordered, nil)
+		}
+		for i, c := range children {
+			ordered[len(children)-1-i] = c
+		}
+		el.flowOrdered = ordered
+	}
+	stretch := layout&krb.LayoutCrossStretchBit != 0
+	for _, c := range ordered {
+		mainLen := c.Width
+		if column {
+			mainLen = c.Height
+		}
+		if column {
+			mainLen = clampMain(mainLen, c.MinHeightDim, c.MaxHeightDim, mainSize)
+			c.Y = contentY + cursor
+			c.Height = mainLen
+			if stretch {
+				c.X = contentX
+				c.Width = clampMain(contentW, c.MinWidthDim, c.MaxWidthDim, contentW)
+			} else {
+				c.X = alignCross(el, c, contentX, contentW)
+			}
+		} else {
+			mainLen = clampMain(mainLen, c.MinWidthDim, c.MaxWidthDim, mainSize)
+			c.X = contentX + cursor
+			c.Width = mainLen
+			if stretch {
+				c.Y = contentY
+				c.Height = clampMain(contentH, c.MinHeightDim, c.MaxHeightDim, contentH)
+			} else {
+				c.Y = alignCross(el, c, contentY, contentH)
+			}
+		}
+		cursor += mainLen + el.Gap
+	}
+
+	if el.Type == krb.ElemTypeScrollable {
+		extent := float32(0)
+		if len(children) > 0 {
+			extent = cursor - el.Gap
+		}
+		if column {
+			el.ContentWidth = contentW
+			el.ContentHeight = maxFloat32(extent, contentH)
+		} else {
+			el.ContentWidth = maxFloat32(extent, contentW)
+			el.ContentHeight = contentH
+		}
+		el.ScrollOffsetX = clampFloat32(el.ScrollOffsetX, 0, el.ContentWidth-contentW)
+		el.ScrollOffsetY = clampFloat32(el.ScrollOffsetY, 0, el.ContentHeight-contentH)
+		el.appliedScrollOffsetX, el.appliedScrollOffsetY = el.ScrollOffsetX, el.ScrollOffsetY
+		for _, c := range children {
+			c.X -= el.ScrollOffsetX
+			c.Y -= el.ScrollOffsetY
+		}
+	}
+}
+
+// clampMain resolves minDim/maxDim (percentages against containing, the
+// parent's content size on this axis) and clamps val to the result. A
+// zero Dimension resolves to 0, so an unset min never raises val and an
+// unset max (which would resolve to 0 too) is treated as "no limit".
+func clampMain(val float32, minDim, maxDim Dimension, containing float32) float32 {
+	if min := minDim.Resolve(containing); min > 0 && val < min {
+		val = min
+	}
+	if max := maxDim.Resolve(containing); max > 0 && val > max {
+		val = max
+	}
+	return val
+}
+
+// resolveGrowLens assigns every grow child among children its main-axis
+// share of mainSize -- basis + a proportional slice of whatever's left
+// over, weighted by EffectiveGrowFactor -- writing the result straight
+// into that child's Width (row) or Height (column) so the caller can
+// just read it back afterward. A non-grow child is untouched; its size
+// was already folded into fixedTotal.
+//
+// Unlike a plain proportional split, no grow child is ever given less
+// than effectiveMinMain: whichever children a first pass would push
+// below their minimum are frozen there instead, and the space that frees
+// up is redistributed among the children that haven't hit their minimum
+// yet, repeating until nothing more is violated -- the same fixed-point
+// iteration flexbox uses for min-content violations. If every grow
+// child's minimum is claimed and the container still doesn't fit, they
+// keep their minimums and the container overflows, the same as
+// layoutFlowChildren's "fixed-size children exceed content size" case
+// for non-grow children -- collapsing a label to 3px so its neighbor can
+// have its full share would be worse than a readable overflow.
+func resolveGrowLens(children []*RenderElement, column bool, mainSize, fixedTotal, growFactorTotal float32) {
+	if growFactorTotal <= 0 {
+		return
+	}
+	type grower struct {
+		el     *RenderElement
+		min    float32
+		frozen bool
+	}
+	growers := make([]grower, 0, len(children))
+	for _, c := range children {
+		if c.EffectiveLayout&krb.LayoutGrowBit != 0 {
+			growers = append(growers, grower{el: c, min: effectiveMinMain(c, column, mainSize)})
+		}
+	}
+
+	remaining := mainSize - fixedTotal
+	factorTotal := growFactorTotal
+	for factorTotal > 0 && remaining > 0 {
+		unit := remaining / factorTotal
+		violated := false
+		for i := range growers {
+			g := &growers[i]
+			if g.frozen {
+				continue
+			}
+			share := g.el.FlexBasisDim.Resolve(mainSize) + unit*EffectiveGrowFactor(g.el)
+			if share < g.min {
+				g.frozen = true
+				remaining -= g.min
+				factorTotal -= EffectiveGrowFactor(g.el)
+				setMain(g.el, column, g.min)
+				violated = true
+			}
+		}
+		if !violated {
+			for i := range growers {
+				g := &growers[i]
+				if g.frozen {
+					continue
+				}
+				setMain(g.el, column, g.el.FlexBasisDim.Resolve(mainSize)+unit*EffectiveGrowFactor(g.el))
+			}
+			return
+		}
+	}
+	// Either every grower hit its minimum (factorTotal exhausted) or
+	// there was no leftover space to divide at all -- whichever grow
+	// children aren't already frozen at their minimum get their basis,
+	// clamped up to their minimum if that's larger.
+	for i := range growers {
+		g := &growers[i]
+		if g.frozen {
+			continue
+		}
+		basis := g.el.FlexBasisDim.Resolve(mainSize)
+		if basis < g.min {
+			basis = g.min
+		}
+		setMain(g.el, column, basis)
+	}
+}
+
+// effectiveMinMain returns c's floor on the main axis: its explicit
+// MinWidthDim/MinHeightDim, or, for a Text leaf along the row axis, its
+// intrinsic single-line width if that's larger. This package has no text
+// wrapping (see fitContentSize), so a Text element's minimum readable
+// width is its full estimated width, the same estimatedCharWidth
+// approximation fitContentSize uses -- there's no font metrics here to
+// do better. Anything else (a container, an image that can shrink freely
+// via ObjectFit) has no intrinsic floor of its own.
+func effectiveMinMain(c *RenderElement, column bool, containing float32) float32 {
+	minDim := c.MinWidthDim
+	if column {
+		minDim = c.MinHeightDim
+	}
+	min := minDim.Resolve(containing)
+	if !column && c.Type == krb.ElemTypeText {
+		if intrinsic := float32(len([]rune(c.TextContent))) * estimatedCharWidth; intrinsic > min {
+			min = intrinsic
+		}
+	}
+	return min
+}
+
+// setMain writes v into c's main-axis field for column/row respectively,
+// the destination resolveGrowLens assigns a grow child's resolved share
+// into.
+func setMain(c *RenderElement, column bool, v float32) {
+	if column {
+		c.Height = v
+	} else {
+		c.Width = v
+	}
+}
+
+// EffectiveGrowFactor returns c's PropIDGrowFactor value, or 1 if unset
+// (the default, meaning "no explicit weight") -- so a tree with no grow
+// factors declared distributes leftover main-axis space equally among
+// its grow children, exactly as it did before this property existed. A
+// sidebar/main-content pair with grow factors 1 and 3 splits leftover
+// space 1:3 instead of 1:1; see PropIDGrowFactor.
+func EffectiveGrowFactor(c *RenderElement) float32 {
+	if c.GrowFactor > 0 {
+		return c.GrowFactor
+	}
+	return 1
+}
+
+// alignCross positions a child along the axis perpendicular to the main
+// flow axis according to the parent's alignment bits.
+func alignCross(parent, child *RenderElement, contentStart, contentSize float32) float32 {
+	column := parent.EffectiveLayout&krb.LayoutDirectionMask == krb.LayoutDirectionColumn ||
+		parent.EffectiveLayout&krb.LayoutDirectionMask == krb.LayoutDirectionColRev
+	childCross := child.Width
+	if column {
+		childCross = child.Width
+	} else {
+		childCross = child.Height
+	}
+
+	switch parent.EffectiveLayout & krb.LayoutAlignmentMask {
+	case krb.LayoutAlignmentCenter:
+		return contentStart + (contentSize-childCross)/2
+	case krb.LayoutAlignmentEnd:
+		return contentStart + (contentSize - childCross)
+	default:
+		return contentStart
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
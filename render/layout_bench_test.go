@@ -0,0 +1,61 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// benchmarkTree builds a 551-element synthetic tree: a column root
+// containing 50 row containers of 10 fixed-size children each, deep
+// enough to exercise layoutFlowChildren at more than one level without
+// tripping the "fixed-size children exceed container" clamp path.
+func benchmarkTree() *RenderElement {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionColumn, Width: 1000, Height: 5000})
+	rows := make([]*RenderElement, 50)
+	for i := range rows {
+		row := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow, Width: 1000, Height: 100})
+		row.Parent = root
+		children := make([]*RenderElement, 10)
+		for j := range children {
+			c := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Width: 100, Height: 100})
+			c.Parent = row
+			children[j] = c
+		}
+		row.Children = children
+		rows[i] = row
+	}
+	root.Children = rows
+	return root
+}
+
+// BenchmarkLayoutSteadyState lays out a 551-element tree once to
+// establish laidOut/cached state, then repeatedly re-runs Layout against
+// the same unchanged tree -- the steady-state case layoutElement's
+// Dirty/size-change check and layoutFlowChildren's scratch slices exist
+// for. It should report zero allocs/op once warmed up.
+func BenchmarkLayoutSteadyState(b *testing.B) {
+	root := benchmarkTree()
+	Layout([]*RenderElement{root}, 1000, 5000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Layout([]*RenderElement{root}, 1000, 5000)
+	}
+}
+
+// BenchmarkRenderTreeSteadyState flattens the same unchanged 551-element
+// tree repeatedly, which after the first call should hit RenderTree's
+// cache and allocate nothing.
+func BenchmarkRenderTreeSteadyState(b *testing.B) {
+	root := benchmarkTree()
+	RenderTree(root)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RenderTree(root)
+	}
+}
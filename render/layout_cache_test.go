@@ -0,0 +1,59 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLayoutSkipsRecomputingAnUnchangedSubtree(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Width: 50, Height: 50})
+	child.Parent = root
+	root.Children = []*RenderElement{child}
+	grandchild := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	grandchild.Parent = child
+	child.Children = []*RenderElement{grandchild}
+
+	if errs := Layout([]*RenderElement{root}, 200, 200); len(errs) != 0 {
+		t.Fatalf("first layout pass returned errors: %v", errs)
+	}
+	if !child.laidOut || !grandchild.laidOut {
+		t.Fatalf("expected every element to be marked laid out after the first pass")
+	}
+
+	// Poke a sentinel value directly into a field only layoutFlowChildren
+	// would touch. If the second pass actually skips recomputation (as
+	// it should, since nothing changed), the sentinel survives.
+	grandchild.X = 12345
+
+	if errs := Layout([]*RenderElement{root}, 200, 200); len(errs) != 0 {
+		t.Fatalf("second layout pass returned errors: %v", errs)
+	}
+	if grandchild.X != 12345 {
+		t.Fatalf("grandchild.X = %v, want the untouched sentinel 12345 (subtree should have been skipped, not recomputed)", grandchild.X)
+	}
+}
+
+func TestLayoutShiftsCachedSubtreeWhenOnlyPositionMoves(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Width: 50, Height: 50})
+	child.Parent = root
+	root.Children = []*RenderElement{child}
+	grandchild := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	grandchild.Parent = child
+	child.Children = []*RenderElement{grandchild}
+
+	Layout([]*RenderElement{root}, 200, 200)
+	before := grandchild.X
+
+	// Move child without resizing or marking it dirty.
+	child.X += 40
+	layoutElement(child, &[]error{})
+
+	if got := grandchild.X - before; got != 40 {
+		t.Fatalf("grandchild.X shifted by %v, want 40", got)
+	}
+}
@@ -0,0 +1,23 @@
+package render
+
+import "fmt"
+
+// LayoutError reports a constraint the layout engine couldn't satisfy
+// exactly -- e.g. children whose fixed sizes plus gaps already exceed
+// their container, or padding larger than the element itself. Layout
+// still produces a best-effort result (it clamps rather than panics or
+// produces negative sizes downstream), but callers that want to catch
+// malformed or over-constrained KRB files during development should
+// check these.
+type LayoutError struct {
+	Element *RenderElement
+	Message string
+}
+
+func (e *LayoutError) Error() string {
+	id := e.Element.ID
+	if id == "" {
+		id = fmt.Sprintf("#%d", e.Element.Source.Index)
+	}
+	return fmt.Sprintf("render: layout: element %s: %s", id, e.Message)
+}
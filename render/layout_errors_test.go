@@ -0,0 +1,27 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLayoutReportsOverconstrainedChildren(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	rootSrc := &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow}
+	root := newRenderElement(doc, rootSrc)
+	root.Width = 50
+	root.Height = 20
+
+	a := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	a.Width = 40
+	b := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	b.Width = 40
+	root.Children = []*RenderElement{a, b}
+	a.Parent, b.Parent = root, root
+
+	errs := Layout([]*RenderElement{root}, 50, 20)
+	if len(errs) == 0 {
+		t.Fatalf("expected a layout error for children wider than their container")
+	}
+}
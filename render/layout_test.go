@@ -0,0 +1,74 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func growChild(i int) *RenderElement {
+	src := &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutGrowBit, Index: i}
+	doc := &krb.Document{Strings: []string{""}}
+	return newRenderElement(doc, src)
+}
+
+func TestLayoutComputesEffectiveVisibilityDownTheParentChain(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	grandparent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeButton})
+	parent.Parent = grandparent
+	grandparent.Children = []*RenderElement{parent}
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+	grandparent.Width, grandparent.Height = 100, 100
+
+	Layout([]*RenderElement{grandparent}, 100, 100)
+	if !grandparent.EffectiveVisible || !parent.EffectiveVisible || !child.EffectiveVisible {
+		t.Fatalf("expected every element visible by default")
+	}
+
+	parent.IsVisible = false
+	Layout([]*RenderElement{grandparent}, 100, 100)
+	if !grandparent.EffectiveVisible {
+		t.Fatalf("hiding parent should not affect grandparent's own EffectiveVisible")
+	}
+	if parent.EffectiveVisible || child.EffectiveVisible {
+		t.Fatalf("expected hiding parent to make both parent and child not EffectiveVisible")
+	}
+}
+
+func TestPixelSnapNoGapsAtFractionalScale(t *testing.T) {
+	const scale = 1.25
+	rowWidth := float32(100 * scale) // 125, splits into three 41.6(6) shares
+
+	doc := &krb.Document{Strings: []string{""}}
+	rootSrc := &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionRow}
+	root := newRenderElement(doc, rootSrc)
+	root.Width = rowWidth
+	root.Height = 10
+
+	children := []*RenderElement{growChild(1), growChild(2), growChild(3)}
+	for _, c := range children {
+		c.Parent = root
+	}
+	root.Children = children
+	rootSrc.Children = []int{1, 2, 3}
+
+	Layout([]*RenderElement{root}, rowWidth, 10)
+
+	total := float32(0)
+	for i, c := range children {
+		total += c.Width
+		if i == 0 {
+			continue
+		}
+		prev := children[i-1]
+		if prev.X+prev.Width != c.X {
+			t.Fatalf("gap/overlap between child %d (ends at %v) and child %d (starts at %v)", i-1, prev.X+prev.Width, i, c.X)
+		}
+	}
+	if total != root.Width {
+		t.Fatalf("snapped child widths (%v) don't sum to the snapped row width (%v)", total, root.Width)
+	}
+}
@@ -0,0 +1,28 @@
+package render
+
+// applyLocaleCustomProperties reads the conventional "lang" custom
+// property -- a BCP-47 language tag such as "ja" or "ar-EG" -- onto
+// Locale. It's a custom property rather than a standard one because the
+// KRB string table is always plain UTF-8 regardless of language; Locale
+// only matters to an app doing its own locale-aware formatting, font
+// fallback, or text-direction decisions.
+func applyLocaleCustomProperties(re *RenderElement) {
+	re.Locale = ""
+	for _, cp := range re.Source.CustomProperties {
+		if re.Doc.String(cp.KeyIndex) == "lang" {
+			re.Locale = customStringValue(re.Doc, cp)
+		}
+	}
+}
+
+// EffectiveLocale returns el's own Locale if set, or the nearest
+// ancestor's, so a single "lang" declared near the root of a tree
+// applies to its whole subtree.
+func (el *RenderElement) EffectiveLocale() string {
+	for e := el; e != nil; e = e.Parent {
+		if e.Locale != "" {
+			return e.Locale
+		}
+	}
+	return ""
+}
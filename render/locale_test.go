@@ -0,0 +1,45 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLocaleCustomProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "lang", "ar-EG"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	})
+	if el.Locale != "ar-EG" {
+		t.Fatalf("Locale = %q, want ar-EG", el.Locale)
+	}
+}
+
+func TestEffectiveLocaleInheritsFromNearestAncestor(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "lang", "ja"}}
+	root := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	child.Parent = root
+
+	if got := child.EffectiveLocale(); got != "ja" {
+		t.Fatalf("EffectiveLocale() = %q, want ja", got)
+	}
+	if got := root.EffectiveLocale(); got != "ja" {
+		t.Fatalf("root EffectiveLocale() = %q, want ja", got)
+	}
+
+	grandchild := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	grandchild.Parent = child
+	if got := grandchild.EffectiveLocale(); got != "ja" {
+		t.Fatalf("grandchild EffectiveLocale() = %q, want ja", got)
+	}
+}
@@ -0,0 +1,40 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestMinMaxWidthClampsAgainstContainerMainAxis(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutGrowBit})
+	child.MaxWidthDim = Dimension{Value: 0.25, IsPercent: true} // 25% of the row's content width
+	parent.Children = []*RenderElement{child}
+	child.Parent = parent
+	parent.Width, parent.Height = 200, 100
+
+	errs := Layout([]*RenderElement{parent}, 0, 0)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected layout errors: %v", errs)
+	}
+	if child.Width != 50 {
+		t.Fatalf("Width = %v, want 50 (25%% of parent's 200px content width)", child.Width)
+	}
+}
+
+func TestMinHeightAppliesInColumnFlow(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutDirectionColumn})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child.MinHeightDim = Dimension{Value: 40}
+	parent.Children = []*RenderElement{child}
+	child.Parent = parent
+	parent.Width, parent.Height = 100, 100
+
+	Layout([]*RenderElement{parent}, 0, 0)
+	if child.Height != 40 {
+		t.Fatalf("Height = %v, want 40 (clamped up to MinHeightDim)", child.Height)
+	}
+}
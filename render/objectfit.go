@@ -0,0 +1,91 @@
+package render
+
+// ObjectFit comes from krb.PropIDObjectFit and controls how an image
+// resource is fitted into its element's box when the two don't share an
+// aspect ratio.
+type ObjectFit uint8
+
+const (
+	// ObjectFitFill stretches the image to exactly fill the element's
+	// box, distorting its aspect ratio if they differ. It's the zero
+	// value, reproducing the only behavior this package had before
+	// ObjectFit existed.
+	ObjectFitFill ObjectFit = iota
+	// ObjectFitContain scales the image to fit entirely within the box,
+	// preserving aspect ratio; any leftover space is left uncovered.
+	ObjectFitContain
+	// ObjectFitCover scales the image to fill the box entirely,
+	// preserving aspect ratio by cropping whichever dimension overflows.
+	ObjectFitCover
+	// ObjectFitNone draws the image at its natural size, centered in the
+	// box, neither scaled nor cropped.
+	ObjectFitNone
+)
+
+// ImageRects computes the source rect (in the image's own natural pixel
+// coordinates) and destination rect (in el-local coordinates, i.e.
+// relative to el's own top-left corner) a backend should draw an image
+// of size texW x texH into for el.ObjectFit. A backend applies
+// ImageFlipH/ImageFlipV on top of the returned src rect, and offsets
+// dest by el's own screen position.
+func ImageRects(el *RenderElement, texW, texH float32) (src, dest Rect) {
+	full := Rect{Width: texW, Height: texH}
+	box := Rect{Width: el.Width, Height: el.Height}
+	if texW <= 0 || texH <= 0 || el.Width <= 0 || el.Height <= 0 {
+		return full, box
+	}
+
+	switch el.ObjectFit {
+	case ObjectFitContain:
+		scale := minFloat32(el.Width/texW, el.Height/texH)
+		w, h := texW*scale, texH*scale
+		return full, Rect{X: (el.Width - w) / 2, Y: (el.Height - h) / 2, Width: w, Height: h}
+	case ObjectFitCover:
+		boxAspect := el.Width / el.Height
+		texAspect := texW / texH
+		if texAspect > boxAspect {
+			// The texture is relatively wider than the box: crop its
+			// left/right edges to match the box's aspect ratio.
+			cropW := texH * boxAspect
+			return Rect{X: (texW - cropW) / 2, Width: cropW, Height: texH}, box
+		}
+		// The texture is relatively taller than (or equal to) the box's
+		// aspect ratio: crop its top/bottom edges instead.
+		cropH := texW / boxAspect
+		return Rect{Y: (texH - cropH) / 2, Width: texW, Height: cropH}, box
+	case ObjectFitNone:
+		return full, Rect{X: (el.Width - texW) / 2, Y: (el.Height - texH) / 2, Width: texW, Height: texH}
+	default: // ObjectFitFill
+		return full, box
+	}
+}
+
+func minFloat32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// clampFloat32 clamps val to [min, max]. A max below min (e.g. content
+// smaller than its own viewport) collapses to min, since there's nowhere
+// valid to scroll to.
+func clampFloat32(val, min, max float32) float32 {
+	if max < min {
+		max = min
+	}
+	if val < min {
+		return min
+	}
+	if val > max {
+		return max
+	}
+	return val
+}
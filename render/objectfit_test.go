@@ -0,0 +1,70 @@
+package render
+
+import "testing"
+
+func boxElement(w, h float32) *RenderElement {
+	return &RenderElement{Width: w, Height: h}
+}
+
+func TestImageRectsFillIsIdentity(t *testing.T) {
+	el := boxElement(200, 100)
+	src, dest := ImageRects(el, 400, 400)
+	if src != (Rect{Width: 400, Height: 400}) {
+		t.Fatalf("fill src = %+v, want full texture rect", src)
+	}
+	if dest != (Rect{Width: 200, Height: 100}) {
+		t.Fatalf("fill dest = %+v, want full element box", dest)
+	}
+}
+
+func TestImageRectsContainLettersLongerAxis(t *testing.T) {
+	el := boxElement(200, 100)
+	el.ObjectFit = ObjectFitContain
+	src, dest := ImageRects(el, 400, 400)
+	if src != (Rect{Width: 400, Height: 400}) {
+		t.Fatalf("contain src = %+v, want full texture rect", src)
+	}
+	want := Rect{X: 50, Y: 0, Width: 100, Height: 100}
+	if dest != want {
+		t.Fatalf("contain dest = %+v, want %+v", dest, want)
+	}
+}
+
+func TestImageRectsCoverCropsWiderTexture(t *testing.T) {
+	el := boxElement(100, 100)
+	el.ObjectFit = ObjectFitCover
+	src, dest := ImageRects(el, 400, 200)
+	want := Rect{X: 100, Y: 0, Width: 200, Height: 200}
+	if src != want {
+		t.Fatalf("cover src = %+v, want %+v", src, want)
+	}
+	if dest != (Rect{Width: 100, Height: 100}) {
+		t.Fatalf("cover dest = %+v, want full element box", dest)
+	}
+}
+
+func TestImageRectsCoverCropsTallerTexture(t *testing.T) {
+	el := boxElement(100, 100)
+	el.ObjectFit = ObjectFitCover
+	src, dest := ImageRects(el, 200, 400)
+	want := Rect{X: 0, Y: 100, Width: 200, Height: 200}
+	if src != want {
+		t.Fatalf("cover src = %+v, want %+v", src, want)
+	}
+	if dest != (Rect{Width: 100, Height: 100}) {
+		t.Fatalf("cover dest = %+v, want full element box", dest)
+	}
+}
+
+func TestImageRectsNoneCentersAtNaturalSize(t *testing.T) {
+	el := boxElement(200, 200)
+	el.ObjectFit = ObjectFitNone
+	src, dest := ImageRects(el, 50, 100)
+	if src != (Rect{Width: 50, Height: 100}) {
+		t.Fatalf("none src = %+v, want full texture rect", src)
+	}
+	want := Rect{X: 75, Y: 50, Width: 50, Height: 100}
+	if dest != want {
+		t.Fatalf("none dest = %+v, want %+v", dest, want)
+	}
+}
@@ -0,0 +1,48 @@
+package render
+
+import "math"
+
+// snapToPixels rounds el's edges -- not its origin and size independently
+// -- to whole pixels using round-half-even, then recurses into its
+// children. Rounding the edges (left, top, right, bottom) rather than
+// position and size separately guarantees that two flow siblings whose
+// shared boundary lands on a fractional pixel (e.g. 103.6) snap to the
+// exact same integer on both sides, since round() is applied to that same
+// float value for both the left child's right edge and the right child's
+// left edge. Drawing, borders and scissor rects must consume these
+// snapped values rather than re-truncating the pre-snap floats.
+func snapToPixels(el *RenderElement) {
+	left := roundHalfEven(el.X)
+	top := roundHalfEven(el.Y)
+	right := roundHalfEven(el.X + el.Width)
+	bottom := roundHalfEven(el.Y + el.Height)
+
+	el.X = left
+	el.Y = top
+	el.Width = right - left
+	el.Height = bottom - top
+
+	for _, c := range el.Children {
+		snapToPixels(c)
+	}
+}
+
+// roundHalfEven implements banker's rounding: exact halves round to the
+// nearest even integer instead of always away from zero, so repeated
+// snapping of a boundary shared by many elements doesn't systematically
+// drift in one direction.
+func roundHalfEven(v float32) float32 {
+	floor := math.Floor(float64(v))
+	diff := float64(v) - floor
+	switch {
+	case diff < 0.5:
+		return float32(floor)
+	case diff > 0.5:
+		return float32(floor + 1)
+	default:
+		if math.Mod(floor, 2) == 0 {
+			return float32(floor)
+		}
+		return float32(floor + 1)
+	}
+}
@@ -0,0 +1,94 @@
+package render
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// Reload re-parses the KRB file at path and rebuilds the tree via
+// PrepareTree, the same as a fresh Init would, then transfers whatever
+// per-element runtime state a naive reload would otherwise silently
+// discard -- each element's scroll offset, plus dispatcher's Focused and
+// Hovered -- from oldRoots onto the new tree, matched by resolved
+// element ID. An element whose ID is empty, new, or renamed just gets
+// the fresh state PrepareTree already gave it. dispatcher may be nil if
+// the caller doesn't track focus/hover across reloads.
+//
+// Reload doesn't mutate oldRoots or swap anything in place -- the
+// caller is the one holding the roots slice it passed to PollEvents/
+// Draw, so it's the caller's job to start using newRoots once Reload
+// returns. A backend wrapper (e.g. raylib.Renderer.Reload) is the
+// better place to additionally invalidate any cached GPU resources
+// keyed by image path, since render itself knows nothing about those
+// caches.
+func Reload(path string, oldRoots []*RenderElement, dispatcher *InputDispatcher) (newRoots []*RenderElement, cfg WindowConfig, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, WindowConfig{}, fmt.Errorf("render: reload %s: %w", path, err)
+	}
+	doc, err := krb.DecodeBytes(data)
+	if err != nil {
+		return nil, WindowConfig{}, fmt.Errorf("render: reload %s: %w", path, err)
+	}
+	newRoots, cfg, err = PrepareTree(doc)
+	if err != nil {
+		return nil, WindowConfig{}, fmt.Errorf("render: reload %s: %w", path, err)
+	}
+	transferTreeState(oldRoots, newRoots, dispatcher)
+	return newRoots, cfg, nil
+}
+
+// transferTreeState copies scroll offsets, and re-targets dispatcher's
+// Focused/Hovered, from oldRoots onto newRoots' elements sharing the
+// same resolved ID.
+func transferTreeState(oldRoots, newRoots []*RenderElement, dispatcher *InputDispatcher) {
+	newByID := indexElementsByID(newRoots)
+	for id, oldEl := range indexElementsByID(oldRoots) {
+		if newEl, ok := newByID[id]; ok {
+			newEl.ScrollOffsetX = oldEl.ScrollOffsetX
+			newEl.ScrollOffsetY = oldEl.ScrollOffsetY
+		}
+	}
+
+	if dispatcher == nil {
+		return
+	}
+	dispatcher.Focused = retargetElement(dispatcher.Focused, newByID)
+	dispatcher.Hovered = retargetElement(dispatcher.Hovered, newByID)
+	dispatcher.HoverElapsed = 0
+	dispatcher.pressed = [3]*RenderElement{}
+}
+
+// retargetElement maps el, an element from the old tree, to its
+// same-ID counterpart in newByID, or nil if el has no ID or no match --
+// dropping focus/hover rather than pointing it at a stale element the
+// new tree doesn't actually contain.
+func retargetElement(el *RenderElement, newByID map[string]*RenderElement) *RenderElement {
+	if el == nil {
+		return nil
+	}
+	return newByID[el.ID]
+}
+
+// indexElementsByID walks roots and returns a map of every
+// non-empty-ID element keyed by that ID. Elements sharing an ID (which
+// PrepareTree doesn't forbid) are not supported -- the last one found
+// wins, same as any other ID-keyed lookup in this package.
+func indexElementsByID(roots []*RenderElement) map[string]*RenderElement {
+	out := map[string]*RenderElement{}
+	var walk func(*RenderElement)
+	walk = func(el *RenderElement) {
+		if el.ID != "" {
+			out[el.ID] = el
+		}
+		for _, c := range el.Children {
+			walk(c)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	return out
+}
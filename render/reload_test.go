@@ -0,0 +1,59 @@
+package render
+
+import "testing"
+
+func TestTransferTreeStateCarriesScrollOffsetAcrossMatchingID(t *testing.T) {
+	old := &RenderElement{ID: "list", ScrollOffsetX: 5, ScrollOffsetY: 40}
+	fresh := &RenderElement{ID: "list"}
+	transferTreeState([]*RenderElement{old}, []*RenderElement{fresh}, nil)
+	if fresh.ScrollOffsetX != 5 || fresh.ScrollOffsetY != 40 {
+		t.Fatalf("scroll offsets = (%v, %v), want (5, 40)", fresh.ScrollOffsetX, fresh.ScrollOffsetY)
+	}
+}
+
+func TestTransferTreeStateDropsStateForRemovedElement(t *testing.T) {
+	old := &RenderElement{ID: "gone", ScrollOffsetY: 40}
+	fresh := &RenderElement{ID: "stays"}
+	transferTreeState([]*RenderElement{old}, []*RenderElement{fresh}, nil)
+	if fresh.ScrollOffsetY != 0 {
+		t.Fatalf("ScrollOffsetY = %v, want 0 (no matching ID)", fresh.ScrollOffsetY)
+	}
+}
+
+func TestTransferTreeStateRetargetsFocusedAndHoveredByID(t *testing.T) {
+	oldFocused := &RenderElement{ID: "btn"}
+	oldHovered := &RenderElement{ID: "link"}
+	newFocused := &RenderElement{ID: "btn"}
+	newHovered := &RenderElement{ID: "link"}
+	d := &InputDispatcher{Focused: oldFocused, Hovered: oldHovered, HoverElapsed: 3}
+
+	transferTreeState([]*RenderElement{oldFocused, oldHovered}, []*RenderElement{newFocused, newHovered}, d)
+
+	if d.Focused != newFocused {
+		t.Fatalf("Focused did not retarget to the new tree's matching element")
+	}
+	if d.Hovered != newHovered {
+		t.Fatalf("Hovered did not retarget to the new tree's matching element")
+	}
+	if d.HoverElapsed != 0 {
+		t.Fatalf("HoverElapsed = %v, want reset to 0", d.HoverElapsed)
+	}
+}
+
+func TestTransferTreeStateClearsFocusWhenElementRemoved(t *testing.T) {
+	oldFocused := &RenderElement{ID: "gone"}
+	d := &InputDispatcher{Focused: oldFocused}
+	transferTreeState(nil, []*RenderElement{{ID: "other"}}, d)
+	if d.Focused != nil {
+		t.Fatalf("Focused = %v, want nil (no matching ID in new tree)", d.Focused)
+	}
+}
+
+func TestIndexElementsByIDWalksChildren(t *testing.T) {
+	child := &RenderElement{ID: "child"}
+	root := &RenderElement{ID: "root", Children: []*RenderElement{child}}
+	idx := indexElementsByID([]*RenderElement{root})
+	if idx["root"] != root || idx["child"] != child {
+		t.Fatalf("indexElementsByID did not find both root and child by ID")
+	}
+}
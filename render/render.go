@@ -0,0 +1,744 @@
+// Package render turns a decoded krb.Document into a tree of
+// RenderElement nodes ready for layout and drawing, independent of any
+// particular graphics backend.
+package render
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// RenderElement is one node of the live UI tree built from a
+// krb.Document. It carries resolved (post-style) visual properties; raw
+// KRB data stays reachable via Source for anything a backend needs that
+// this package doesn't resolve itself.
+type RenderElement struct {
+	Source *krb.Element
+	Doc    *krb.Document
+
+	ID      string // resolved element ID (from the string table), may be ""
+	Type    uint8
+	StyleID uint8 // style currently applied; may differ from Source.StyleID after SetStyle
+
+	X, Y          float32
+	Width, Height float32
+
+	BgColor      Color
+	FgColor      Color
+	BorderColor  Color
+	BorderWidths [4]float32 // top, right, bottom, left; scaled lazily by layout/backends
+
+	// BorderColors holds each side's own border color, same top/right/
+	// bottom/left order as BorderWidths. PropIDBorderColor seeds all four
+	// with BorderColor; PropIDBorderColorTop/Right/Bottom/Left override
+	// one side at a time on top of that, whichever order the properties
+	// were declared in. See BorderSegments for how a backend turns these
+	// (plus BorderStyle) into actual draw calls.
+	BorderColors [4]Color
+
+	// BorderStyle controls how BorderSegments strokes BorderWidths/
+	// BorderColors: solid (the zero value), dashed, or dotted.
+	BorderStyle BorderStyle
+
+	// PaddingDim is top, right, bottom, left, in pixels or as a
+	// percentage of the element's own resolved Width. Padding holds the
+	// pixel value last resolved from it by the layout pass.
+	PaddingDim [4]Dimension
+	Padding    [4]float32
+
+	// MinWidthDim/MaxWidthDim/MinHeightDim/MaxHeightDim clamp an
+	// element's flow-assigned size. Percentages resolve against the
+	// parent's content size on the matching axis (the "containing
+	// block", per CSS terminology) rather than always against width the
+	// way padding does, since a height constraint expressed as a
+	// percentage of the parent's width would rarely be what's intended.
+	MinWidthDim  Dimension
+	MaxWidthDim  Dimension
+	MinHeightDim Dimension
+	MaxHeightDim Dimension
+
+	bgColorSource     colorSource
+	fgColorSource     colorSource
+	borderColorSource colorSource
+
+	TextContent string
+
+	// TextKey is the raw string-table value PropIDTextContent decoded to,
+	// before any active Translations replaced it -- TextContent's
+	// original, untranslated form, by either a literal string or an
+	// explicit "@key"-style message key. SetTranslations re-resolves
+	// TextContent from this each time the active translations change, so
+	// switching locales (or clearing them) always recovers exactly what
+	// the KRB file declared.
+	TextKey string
+
+	// ImagePath is the resolved path (or, for inline resources, the
+	// string table entry recorded as its name) of an ElemTypeImage's
+	// source resource. Empty when the element has no PropIDImageSource.
+	ImagePath string
+
+	// ImageTint modulates an image's drawn colors (e.g. to recolor an
+	// icon, or fade it when disabled) without needing a second copy of
+	// the asset. It defaults to opaque white, i.e. no modulation.
+	ImageTint       Color
+	imageTintSource colorSource
+
+	// ImageFlipH and ImageFlipV mirror an image horizontally/vertically,
+	// e.g. to reuse one asset for both left- and right-facing sprites.
+	ImageFlipH bool
+	ImageFlipV bool
+
+	// TintFromFg is set by the conventional "tintIcon" custom property. It
+	// makes ImageTint track FgColor -- including on a later SetStyle or
+	// theme switch -- instead of requiring a separate literal/token tint,
+	// the common case for a monochrome icon that should just match its
+	// element's foreground color (e.g. a tabbar icon tinted with the
+	// active tab's accent color).
+	TintFromFg bool
+
+	// Grayscale desaturates an image when drawn, via the conventional
+	// "grayscale" custom property -- e.g. to show a disabled icon without
+	// needing a second copy of the asset. A backend is expected to cache
+	// any desaturated texture separately from its normal texture cache,
+	// since the original is very likely shared with other elements.
+	Grayscale bool
+
+	// Opacity scales an element's drawn alpha, decoded from
+	// krb.PropIDOpacity (an 8.8 fixed-point percentage, 0.0-1.0). It
+	// defaults to 1 (fully opaque). See ResolvedImageTint for where it's
+	// folded into image drawing; a backend applying it to other drawing
+	// (background/border/text) should do so the same way, by multiplying
+	// it into that draw call's alpha rather than reading it separately.
+	Opacity float32
+
+	// ObjectFit controls how an image is scaled/cropped into this
+	// element's box when the two don't share an aspect ratio. See
+	// ImageRects, which a backend calls to get the src/dest rects this
+	// mode implies.
+	ObjectFit ObjectFit
+
+	// FrameCount, SpriteCols, SpriteRows and FPS describe an optional
+	// sprite-sheet animation decoded from krb.PropIDSpriteAnimation, read
+	// off the image at ImagePath. FrameCount == 0 (the default) means
+	// ImagePath is a single static frame; see SpriteFrameRect.
+	FrameCount int
+	SpriteCols int
+	SpriteRows int
+	FPS        float32
+
+	// CurrentFrame is the 0-indexed, row-major frame a sprite-sheet
+	// animation is currently showing. AdvanceAnimations owns it; a
+	// backend should only read it (via SpriteFrameRect).
+	CurrentFrame int
+	frameElapsed float32
+
+	// ScrollOffsetX and ScrollOffsetY shift a krb.ElemTypeScrollable
+	// container's children opposite to the scroll direction, revealing
+	// content beyond its own box. layoutFlowChildren clamps both to
+	// [0, ContentWidth-Width] / [0, ContentHeight-Height]; see
+	// ScrollIntoView for programmatically bringing a descendant into view.
+	ScrollOffsetX, ScrollOffsetY float32
+
+	// ContentWidth and ContentHeight are the full extent of a
+	// krb.ElemTypeScrollable container's children along each axis, before
+	// ScrollOffsetX/Y is applied -- i.e. how far it can scroll. Computed
+	// by Layout; meaningless on a non-scrollable element.
+	ContentWidth, ContentHeight float32
+
+	// ClipToParent requests that a backend restrict drawing of this
+	// element and its descendants to its parent's bounds. It's mainly
+	// useful on an absolutely positioned element (krb.LayoutAbsoluteBit)
+	// that would otherwise be free to draw outside the area its parent
+	// visually occupies, e.g. a dropdown that shouldn't bleed past a
+	// scrollable container's edge.
+	ClipToParent bool
+
+	// CustomData is the most recently applied PropIDCustomDataBlob
+	// payload, if the element has one (nil otherwise). The runtime
+	// doesn't interpret it; it's an escape hatch for app-specific
+	// per-element data a KRY compiler extension wants to carry through to
+	// the application unmodified. It's resolved through the same layering
+	// as any other property -- including component expansion, so the
+	// blob an instance (or its template) carries is visible on the
+	// expanded root -- and is a defensive copy, safe to retain or modify
+	// without affecting the parsed krb.Document. See CustomDataBlobs for
+	// every blob the element carries, if a compiler emits more than one.
+	CustomData []byte
+
+	// CustomDataBlobs holds every PropIDCustomDataBlob payload resolved
+	// onto the element, in the order properties were applied, each a
+	// defensive copy. CustomData is always CustomDataBlobs' last entry.
+	CustomDataBlobs [][]byte
+
+	// GapDim is the spacing between flow-layout children, in pixels or
+	// as a percentage of the element's own resolved main-axis size. Gap
+	// holds the pixel value last resolved from it by the layout pass.
+	GapDim Dimension
+	Gap    float32
+
+	// FlexBasisDim is a grow child's preferred main-axis size (see
+	// PropIDFlexBasis), reserved before any leftover space is divided
+	// among grow siblings by GrowFactor. It resolves against the
+	// container's content size on the main axis, the same as MinWidthDim/
+	// MaxWidthDim. Zero (the default) reserves nothing, matching grow's
+	// original all-leftover-space behavior.
+	FlexBasisDim Dimension
+
+	// GrowFactor is how large a share of leftover main-axis space this
+	// element takes relative to its grow siblings, set via
+	// PropIDGrowFactor. Zero (the default, meaning "unset") is treated as
+	// 1 everywhere it's read, so equal-weight distribution is unchanged
+	// when no element declares a factor.
+	GrowFactor float32
+
+	Parent   *RenderElement
+	Children []*RenderElement
+
+	IsInteractive bool
+
+	// Cursor is the mouse cursor shape declared for this element via
+	// PropIDCursor, CursorDefault if unset. A backend's hit-testing/hover
+	// loop resolves the cursor to actually show from the hovered
+	// element's Cursor, falling back to CursorPointer for an
+	// IsInteractive element that didn't declare one explicitly.
+	Cursor Cursor
+
+	// Tooltip is the text declared for this element via PropIDTooltip,
+	// empty if unset. A backend shows it in a small floating box near the
+	// cursor once the element has been continuously hovered past a short
+	// delay; see InputDispatcher.AdvanceHover and TooltipTarget.
+	Tooltip string
+
+	// TooltipKey is Tooltip's raw, untranslated string-table value, the
+	// same way TextKey relates to TextContent.
+	TooltipKey string
+
+	// Masked and NumericOnly apply to ElemTypeInput elements; see
+	// input.go. They're derived from the element's own custom properties,
+	// not from standard properties or styles.
+	Masked      bool
+	NumericOnly bool
+	Placeholder string
+	Disabled    bool
+
+	// HitPadding grows an IsInteractive element's hit-testing rectangle
+	// by this many pixels on every side, from the conventional
+	// "hitPadding" custom property. It never affects drawing or layout,
+	// and a non-interactive element never gets one; see
+	// InputDispatcher.SetMinimumHitTarget for the renderer-wide version
+	// of the same accommodation.
+	HitPadding float32
+
+	// InheritBackground is set from the conventional "inheritBackground"
+	// custom property. When true, el's own resolved BgColor cascades as
+	// the default background for any Container descendant that doesn't
+	// set its own; see resolvePropertyInheritance.
+	InheritBackground bool
+
+	// disabledEvents masks dispatch of specific event types for this
+	// element only, set via SetEventEnabled (and seeded from the
+	// conventional "eventsDisabled" custom property at build time). Unlike
+	// Disabled, which is part of the restyle chain and takes the element
+	// out of dispatch entirely, this is deliberately left untouched by
+	// resetToBaseDefaults/SetStyle, so it survives a restyle and only
+	// resets when the element itself is rebuilt (document reload).
+	disabledEvents map[uint8]bool
+
+	// Ellipsis is set from the conventional "text_overflow: ellipsis"
+	// custom property; see TruncateWithEllipsis for the actual
+	// truncation, which a backend applies at draw time once it knows its
+	// own font metrics.
+	Ellipsis bool
+
+	// VerticalAlign is set from the conventional "vertical_align" custom
+	// property and controls how a text element's line is positioned
+	// within its own box on the cross axis; see TextVerticalOffset for
+	// the actual placement math, which a backend applies once it knows
+	// its own line height.
+	VerticalAlign VerticalAlign
+
+	// Selectable is set from the conventional "selectable: true" custom
+	// property, opting a read-only Text element into drag-to-select (see
+	// CharIndexAtX, SelectedText).
+	Selectable bool
+
+	// SelectionStart and SelectionEnd are rune indices into TextContent
+	// bounding the current selection on a Selectable element; equal
+	// values (the default) mean no selection. Unlike Start/End's usual
+	// connotation, Start is not guaranteed <= End -- it's the drag
+	// anchor, so it can be on either side of End depending on which way
+	// the selection was dragged; see SelectedText.
+	SelectionStart, SelectionEnd int
+
+	// Decoration holds the underline/strikethrough/shadow/outline bits
+	// decoded from krb.PropIDTextDecoration.
+	Decoration TextDecoration
+
+	// TextShadowOffsetX, TextShadowOffsetY and TextShadowColor come from
+	// krb.PropIDTextShadow and only matter when Decoration has
+	// TextDecorationShadow set. A backend's text draw pass is expected to
+	// draw the text once at (X+TextShadowOffsetX, Y+TextShadowOffsetY) in
+	// TextShadowColor before its normal pass in FgColor -- purely a
+	// second draw call, so it never affects intrinsic text measurement
+	// the way LetterSpacing/LineHeight do.
+	TextShadowOffsetX, TextShadowOffsetY float32
+	TextShadowColor                      Color
+
+	// TextOutlineColor comes from krb.PropIDTextOutlineColor and only
+	// matters when Decoration has TextDecorationOutline set. A backend's
+	// text draw pass is expected to draw the text several times offset in
+	// a ring (e.g. the 8 surrounding pixel offsets) in TextOutlineColor
+	// before its normal pass, an acceptable approximation of a true
+	// outline without SDF font rendering.
+	TextOutlineColor Color
+
+	// Locale is the conventional "lang" custom property: a BCP-47
+	// language tag (e.g. "ja", "ar-EG") an app can use for its own
+	// locale-aware formatting or font-selection decisions. See
+	// EffectiveLocale for inheriting it from an ancestor.
+	Locale string
+
+	// LetterSpacing and LineHeight come from krb.PropIDLetterSpacing and
+	// krb.PropIDLineHeight. Both default to 0, meaning "use the font's
+	// natural spacing/line height" -- the behavior before these
+	// properties existed. A backend's text measurement and drawing must
+	// account for both once it renders text; see TruncateWithEllipsis for
+	// the equivalent caveat about needing backend font metrics.
+	LetterSpacing float32
+	LineHeight    float32
+
+	// Anchor, OffsetX and OffsetY are the conventional "anchor" /
+	// "offsetX" / "offsetY" custom properties, used by an absolutely
+	// positioned element to pin itself to a parent corner or center; see
+	// applyAnchorCustomProperties and layoutFlowChildren.
+	Anchor           string
+	OffsetX, OffsetY float32
+
+	// FitContent is the conventional "fit_content" custom property: when
+	// set, the element shrinks to the extent of its children on both
+	// axes instead of carrying whatever Width/Height it would otherwise
+	// have going into layout. See fitContentSize.
+	FitContent bool
+
+	// OnClick fires on a krb.EventTypeClick in addition to (and before)
+	// any KRB-declared handler. It's for elements created at runtime
+	// rather than read from a KRB file -- e.g. ToastManager's toasts --
+	// which have no Callback ID string to look up in the handlers
+	// registry.
+	OnClick HandlerFunc
+
+	// IsVisible comes from krb.PropIDVisibility and defaults to true.
+	// It's el's own visibility only; see EffectiveVisible for whether el
+	// is actually visible once its ancestors are accounted for.
+	IsVisible bool
+
+	// EffectiveVisible is IsVisible ANDed down the Parent chain, so a
+	// hidden container's descendants read as not visible even though
+	// their own IsVisible is still true. It's computed once per Layout
+	// call (see computeEffectiveVisibility) rather than walked on demand,
+	// since both drawing and hit-testing need it for every element every
+	// frame. Before the first Layout call it defaults to true, matching
+	// IsVisible's own default.
+	EffectiveVisible bool
+
+	// EffectiveLayout is the Layout byte layoutFlowChildren and friends
+	// actually arrange by. It starts out equal to Source.Layout and stays
+	// that way unless a "layout@<breakpoint>" custom property overrides
+	// the direction bits for the currently active breakpoint; see
+	// resolveBreakpoints. Reading this instead of Source.Layout directly
+	// is what lets a breakpoint override take effect without mutating
+	// the underlying krb.Element.
+	EffectiveLayout uint8
+
+	// Dirty is set whenever something changes this element's computed
+	// layout inputs (style, size, ...) and cleared by the layout pass
+	// once it has recomputed the subtree.
+	Dirty bool
+
+	// breakpointOverrides is the precomputed "<property>@<breakpoint>"
+	// custom property list for this element; see
+	// applyBreakpointCustomProperties. baseVisible is the IsVisible value
+	// before any breakpoint's "visible@..." override is applied, so
+	// resolveBreakpoints has something stable to fall back to once a
+	// breakpoint that was overriding it stops being active.
+	breakpointOverrides []breakpointOverride
+	baseVisible         bool
+
+	// breakpoints is only ever set on a tree's root (see
+	// PrepareTree/PrepareWindows), which is what Layout consults on each
+	// call to resolve every element's breakpointOverrides against the
+	// current viewport width.
+	breakpoints []BreakpointDef
+
+	// laidOut, cachedWidth, cachedHeight, cachedX and cachedY let Layout
+	// skip recomputing a subtree's flow arrangement when neither its size
+	// nor its contents changed since the last pass; see layoutElement.
+	laidOut                   bool
+	cachedWidth, cachedHeight float32
+	cachedX, cachedY          float32
+
+	// appliedScrollOffsetX/Y is the ScrollOffsetX/Y layoutFlowChildren
+	// last positioned this element's children against, so layoutElement's
+	// cached-subtree path (which never calls layoutFlowChildren) can
+	// still detect a scroll offset changed since then and shift the
+	// subtree by the difference instead of leaving stale, unclamped
+	// content in place; see layoutElement.
+	appliedScrollOffsetX, appliedScrollOffsetY float32
+
+	// flowChildren and flowAbsolute are layoutFlowChildren's scratch
+	// slices for this element's in-flow and absolutely positioned
+	// children, kept around and truncated with [:0] instead of
+	// reallocated on every call -- a container's child count rarely
+	// changes between frames, so the backing array only grows the first
+	// few times layoutFlowChildren actually runs against it.
+	flowChildren, flowAbsolute, flowOrdered []*RenderElement
+
+	// treeVersion counts MarkDirty calls against el or any descendant,
+	// so RenderTree can tell whether its cached flattened slice for this
+	// root is still valid without re-walking the tree to check.
+	treeVersion uint64
+
+	// flatCache and flatCacheVersion are RenderTree's memoized result for
+	// this element as a root, valid as long as flatCacheVersion still
+	// matches treeVersion.
+	flatCache        []*RenderElement
+	flatCacheVersion uint64
+}
+
+// WindowConfig captures the App element's window-level configuration.
+// Fields are zero-valued (and Title empty) when no App element is present;
+// use DefaultWindowConfig for the Default* styling fallbacks in that case.
+type WindowConfig struct {
+	Width       uint16
+	Height      uint16
+	Title       string
+	Resizable   bool
+	KeepAspect  bool
+	ScaleFactor float32
+
+	// Borderless and AlwaysOnTop support HUD/overlay-style apps (e.g. an
+	// on-screen display or a always-visible widget bar). They're read
+	// from conventional custom properties ("borderless", "always_on_top")
+	// on the App element rather than standard App-Specific properties,
+	// since most apps never need them.
+	Borderless  bool
+	AlwaysOnTop bool
+
+	// MinWidth and MinHeight are the conventional "min_width"/
+	// "min_height" custom properties on the App element, passed to a
+	// backend's window-min-size API so a user can't shrink the window
+	// until layout degenerates into zero-size elements. Zero (the
+	// default) means no minimum is enforced.
+	MinWidth, MinHeight uint16
+
+	// ResizeDebounceMs is the conventional "resize_debounce_ms" custom
+	// property: while an interactive resize is in progress, a backend
+	// should relayout at most this often, doing one final exact relayout
+	// once the size stabilizes, rather than relaying out on every single
+	// resize event. Zero (the default) means relayout immediately on
+	// every resize, matching the behavior before this property existed.
+	ResizeDebounceMs uint16
+
+	// TargetFPS is the conventional "target_fps" custom property: a
+	// backend should cap its render loop to this rate rather than
+	// running as fast as the display allows, easier on a battery- or
+	// thermally-constrained device. Zero means "use the backend's own
+	// default", which DefaultWindowConfig sets to 60 to preserve the
+	// frame rate every app got before this property existed; an app
+	// that genuinely wants an uncapped loop sets VSync instead, or an
+	// explicit high TargetFPS.
+	TargetFPS uint16
+
+	// VSync is the conventional "vsync" custom property: when true, a
+	// backend should sync frame presentation to the display's refresh
+	// rate (raylib's FlagVsyncHint) instead of racing to TargetFPS,
+	// eliminating tearing at the cost of not being able to render faster
+	// than the display refreshes. False (the default) matches the
+	// behavior before this property existed.
+	VSync bool
+
+	// Breakpoints is parsed from the conventional "breakpoints" custom
+	// property (e.g. "narrow<600,wide>=600") on the App element. It's
+	// attached to the tree's root by PrepareTree/PrepareWindows, which is
+	// what Layout actually consults each relayout; see resolveBreakpoints.
+	Breakpoints []BreakpointDef
+
+	// DefaultBgColor, DefaultFgColor, DefaultBorderColor, DefaultFontSize
+	// and DefaultFontFamily are the root-of-inheritance defaults used
+	// when a property is inheritable (see kryon_runtime_guide.md section
+	// 4) and remains unset all the way up to the App element. They start
+	// out at DefaultWindowConfig's values and are overridden by the App
+	// element's own standard properties, if it sets them.
+	DefaultBgColor     Color
+	DefaultFgColor     Color
+	DefaultBorderColor Color
+	DefaultFontSize    float32
+	DefaultFontFamily  string
+
+	// DocumentMetadata is the source document's krb.Document.Metadata(),
+	// carried alongside the window settings PrepareTree derives from the
+	// same document so a caller doesn't need to hold onto the
+	// *krb.Document separately just to read its format version, app
+	// version, or author after PrepareTree returns.
+	DocumentMetadata krb.Metadata
+}
+
+// DefaultWindowConfig returns the baseline WindowConfig a runtime should
+// start from before applying an App element's own properties, per
+// kryon_runtime_guide.md section 2.
+func DefaultWindowConfig() WindowConfig {
+	return WindowConfig{
+		ScaleFactor:        1.0,
+		TargetFPS:          60,
+		DefaultBgColor:     Color{R: 30, G: 30, B: 30, A: 255},
+		DefaultFgColor:     Color{R: 255, G: 255, B: 255, A: 255},
+		DefaultBorderColor: Color{R: 128, G: 128, B: 128, A: 255},
+		DefaultFontSize:    18.0,
+	}
+}
+
+// PrepareTree walks doc.Elements and builds the render tree, resolving
+// the App element's window configuration along the way.
+//
+// When krb.FlagHasApp is set, every element is searched for
+// krb.ElemTypeApp rather than assuming it is element 0 -- compilers are
+// free to emit wrapper elements ahead of it. If more than one App element
+// is found, the first one encountered wins and the rest are logged as a
+// warning. The chosen App element becomes the root of the returned tree;
+// any other parentless element is reported as an orphan rather than
+// silently dropped or silently promoted to a second root.
+func PrepareTree(doc *krb.Document) (roots []*RenderElement, cfg WindowConfig, err error) {
+	if doc == nil {
+		return nil, WindowConfig{}, fmt.Errorf("render: nil document")
+	}
+
+	built, hasParent := buildTree(doc)
+	if err := expandComponents(doc, built); err != nil {
+		return nil, WindowConfig{}, err
+	}
+
+	var appRoot *RenderElement
+	if doc.Header.HasFlag(krb.FlagHasApp) {
+		var appIndices []int
+		for i, el := range doc.Elements {
+			if el.Type == krb.ElemTypeApp {
+				appIndices = append(appIndices, i)
+			}
+		}
+		switch len(appIndices) {
+		case 0:
+			log.Printf("render: FlagHasApp set but no App element found; using defaults")
+		case 1:
+			appRoot = built[appIndices[0]]
+		default:
+			log.Printf("render: multiple App elements found (%v); using the first and ignoring the rest", appIndices)
+			appRoot = built[appIndices[0]]
+		}
+	}
+
+	if appRoot != nil {
+		cfg = windowConfigFromApp(doc, appRoot.Source)
+		appRoot.breakpoints = cfg.Breakpoints
+	}
+	cfg.DocumentMetadata = doc.Metadata()
+
+	var orphans []*RenderElement
+	for i, el := range built {
+		if hasParent[i] {
+			continue
+		}
+		if el == appRoot {
+			continue
+		}
+		orphans = append(orphans, el)
+	}
+
+	if appRoot != nil {
+		roots = []*RenderElement{appRoot}
+	} else {
+		roots = orphans
+		orphans = nil
+	}
+
+	for _, o := range orphans {
+		log.Printf("render: element %d (%s) has no parent and is not the App root; treating as orphan root", o.Source.Index, o.ID)
+		roots = append(roots, o)
+	}
+
+	resolvePropertyInheritance(roots)
+
+	return roots, cfg, nil
+}
+
+// buildTree constructs a RenderElement for every element in doc, wires up
+// Parent/Children from doc's already-resolved child indices, and reports
+// which elements have a parent (so callers can find root candidates).
+func buildTree(doc *krb.Document) (built []*RenderElement, hasParent []bool) {
+	built = make([]*RenderElement, len(doc.Elements))
+	for i, el := range doc.Elements {
+		built[i] = newRenderElement(doc, el)
+	}
+	for i, el := range doc.Elements {
+		for _, childIdx := range el.Children {
+			if childIdx < 0 || childIdx >= len(built) {
+				continue
+			}
+			child := built[childIdx]
+			child.Parent = built[i]
+			built[i].Children = append(built[i].Children, child)
+		}
+	}
+
+	hasParent = make([]bool, len(built))
+	for _, el := range built {
+		for _, c := range el.Children {
+			hasParent[c.Source.Index] = true
+		}
+	}
+	return built, hasParent
+}
+
+// Window pairs one top-level render tree with the window it should be
+// shown in. It's the unit PrepareWindows hands back for each App element
+// found in a document.
+type Window struct {
+	Root   *RenderElement
+	Config WindowConfig
+}
+
+// PrepareWindows is PrepareTree's multi-window counterpart: rather than
+// collapsing every App element down to a single chosen root, it returns
+// one Window per App element found, each with its own WindowConfig, so a
+// document that declares several App elements can drive several native
+// windows. Non-App parentless elements are still surfaced, each as its
+// own Window with a zero-value Config, exactly as PrepareTree reports
+// them as orphan roots.
+//
+// Callers that only ever expect a single window can keep using
+// PrepareTree; PrepareWindows exists for backends that are prepared to
+// open more than one.
+func PrepareWindows(doc *krb.Document) ([]Window, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("render: nil document")
+	}
+
+	built, hasParent := buildTree(doc)
+	if err := expandComponents(doc, built); err != nil {
+		return nil, err
+	}
+
+	var appRoots []*RenderElement
+	if doc.Header.HasFlag(krb.FlagHasApp) {
+		for _, el := range built {
+			if el.Source.Type == krb.ElemTypeApp {
+				appRoots = append(appRoots, el)
+			}
+		}
+	}
+
+	appSet := make(map[*RenderElement]bool, len(appRoots))
+	for _, a := range appRoots {
+		appSet[a] = true
+	}
+
+	var windows []Window
+	for _, a := range appRoots {
+		cfg := windowConfigFromApp(doc, a.Source)
+		a.breakpoints = cfg.Breakpoints
+		windows = append(windows, Window{Root: a, Config: cfg})
+	}
+	for i, el := range built {
+		if hasParent[i] || appSet[el] {
+			continue
+		}
+		log.Printf("render: element %d (%s) has no parent and is not an App root; treating as its own window", el.Source.Index, el.ID)
+		windows = append(windows, Window{Root: el})
+	}
+
+	return windows, nil
+}
+
+func newRenderElement(doc *krb.Document, el *krb.Element) *RenderElement {
+	idName, _ := doc.ElementIDName(el.ID)
+	re := &RenderElement{
+		Source:        el,
+		Doc:           doc,
+		ID:            idName,
+		Type:          el.Type,
+		X:             float32(el.PosX),
+		Y:             float32(el.PosY),
+		Width:         float32(el.Width),
+		Height:        float32(el.Height),
+		IsInteractive: el.Type == krb.ElemTypeButton || el.Type == krb.ElemTypeInput,
+	}
+	applyStylePropertiesToElement(re, el.StyleID)
+	applyEventsDisabledCustomProperty(re)
+	return re
+}
+
+func windowConfigFromApp(doc *krb.Document, app *krb.Element) WindowConfig {
+	cfg := DefaultWindowConfig()
+	cfg.Width = app.Width
+	cfg.Height = app.Height
+
+	extended := doc.Header.HasFlag(krb.FlagExtendedColor)
+	for _, p := range app.Properties {
+		switch p.ID {
+		case krb.PropIDWindowWidth:
+			if len(p.Value) >= 2 {
+				cfg.Width = le16(p.Value)
+			}
+		case krb.PropIDWindowHeight:
+			if len(p.Value) >= 2 {
+				cfg.Height = le16(p.Value)
+			}
+		case krb.PropIDWindowTitle:
+			if len(p.Value) > 0 {
+				cfg.Title = activeTranslations.resolve(doc.String(p.Value[0]))
+			}
+		case krb.PropIDResizable:
+			if len(p.Value) > 0 {
+				cfg.Resizable = p.Value[0] != 0
+			}
+		case krb.PropIDKeepAspect:
+			if len(p.Value) > 0 {
+				cfg.KeepAspect = p.Value[0] != 0
+			}
+		case krb.PropIDFontSize:
+			if len(p.Value) >= 2 {
+				cfg.DefaultFontSize = float32(le16(p.Value))
+			}
+		case krb.PropIDBackgroundColor:
+			cfg.DefaultBgColor = decodeColor(p.Value, extended)
+		case krb.PropIDForegroundColor:
+			cfg.DefaultFgColor = decodeColor(p.Value, extended)
+		case krb.PropIDBorderColor:
+			cfg.DefaultBorderColor = decodeColor(p.Value, extended)
+		}
+	}
+	for _, cp := range app.CustomProperties {
+		switch doc.String(cp.KeyIndex) {
+		case "borderless":
+			cfg.Borderless = len(cp.Value) > 0 && cp.Value[0] != 0
+		case "always_on_top":
+			cfg.AlwaysOnTop = len(cp.Value) > 0 && cp.Value[0] != 0
+		case "min_width":
+			cfg.MinWidth = uint16(customNumberValue(cp))
+		case "min_height":
+			cfg.MinHeight = uint16(customNumberValue(cp))
+		case "resize_debounce_ms":
+			cfg.ResizeDebounceMs = uint16(customNumberValue(cp))
+		case "target_fps":
+			cfg.TargetFPS = uint16(customNumberValue(cp))
+		case "vsync":
+			cfg.VSync = len(cp.Value) > 0 && cp.Value[0] != 0
+		case "breakpoints":
+			cfg.Breakpoints = parseBreakpoints(customStringValue(doc, cp))
+		}
+	}
+	return cfg
+}
+
+func le16(b []byte) uint16 { return uint16(b[0]) | uint16(b[1])<<8 }
@@ -0,0 +1,68 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// buildDocWithAppAt assembles a minimal document with an App element and
+// a button child of it. For appIndex 0 the App is element 0; for any
+// other appIndex an unwired wrapper container is element 0, the App is
+// element 1 and the button element 2.
+func buildDocWithAppAt(appIndex int) *krb.Document {
+	doc := &krb.Document{
+		Header:  krb.Header{Flags: krb.FlagHasApp},
+		Strings: []string{"", "app", "btn"},
+	}
+
+	app := &krb.Element{Type: krb.ElemTypeApp, ID: 1, Width: 800, Height: 600}
+	btn := &krb.Element{Type: krb.ElemTypeButton, ID: 2}
+
+	switch appIndex {
+	case 0:
+		app.Index, btn.Index = 0, 1
+		app.Children = []int{1}
+		doc.Elements = []*krb.Element{app, btn}
+	default:
+		wrapper := &krb.Element{Type: krb.ElemTypeContainer}
+		wrapper.Index, app.Index, btn.Index = 0, 1, 2
+		app.Children = []int{2}
+		doc.Elements = []*krb.Element{wrapper, app, btn}
+	}
+	return doc
+}
+
+func TestPrepareTreeFindsAppRegardlessOfPosition(t *testing.T) {
+	docFirst := buildDocWithAppAt(0)
+	rootsFirst, cfgFirst, err := PrepareTree(docFirst)
+	if err != nil {
+		t.Fatalf("PrepareTree(appAt0): %v", err)
+	}
+
+	docLater := buildDocWithAppAt(1)
+	rootsLater, cfgLater, err := PrepareTree(docLater)
+	if err != nil {
+		t.Fatalf("PrepareTree(appAt1): %v", err)
+	}
+
+	if !reflect.DeepEqual(cfgFirst, cfgLater) {
+		t.Fatalf("window config differs by App position: %+v vs %+v", cfgFirst, cfgLater)
+	}
+	// docLater's wrapper container isn't wired as the App element's
+	// parent, so it's reported as its own orphan root alongside the App
+	// root -- see PrepareTree's orphan-detection behavior.
+	if len(rootsFirst) != 1 || len(rootsLater) != 2 {
+		t.Fatalf("expected the App root at index 0 and the App root plus the unwired wrapper's orphan root at index 1, got %d and %d", len(rootsFirst), len(rootsLater))
+	}
+	if rootsFirst[0].Type != krb.ElemTypeApp || rootsLater[0].Type != krb.ElemTypeApp {
+		t.Fatalf("root element is not the App element")
+	}
+	if rootsLater[1].Type != krb.ElemTypeContainer {
+		t.Fatalf("expected the wrapper container as an orphan root, got %+v", rootsLater[1])
+	}
+	if len(rootsLater[0].Children) != 1 || rootsLater[0].Children[0].Type != krb.ElemTypeButton {
+		t.Fatalf("App root does not have the button as its child when App is at index 1")
+	}
+}
@@ -0,0 +1,88 @@
+package render
+
+import "image"
+
+// Renderer is the backend contract a graphics library (raylib, a
+// software rasterizer, a headless test double, ...) implements to turn a
+// prepared render tree into pixels on screen.
+//
+// Renderer is intentionally small; backend-specific capabilities that
+// don't make sense for every implementation (e.g. raylib-only texture
+// tricks) live on the concrete backend type instead, surfaced through an
+// optional interface below (TextureLoader, FontLoader, VideoController,
+// Runner) for calling code that wants to use one without depending on a
+// concrete backend type -- see the AsXxx helpers. Building and laying
+// out the tree a Renderer draws (PrepareTree, PrepareWindows, Layout)
+// are free functions in this package rather than interface methods,
+// since they need nothing backend-specific to run.
+type Renderer interface {
+	// Init creates the window/surface described by cfg.
+	Init(cfg WindowConfig) error
+	// PollEvents samples this frame's input and dispatches it against
+	// roots -- updating hover/focus/press state and invoking handlers
+	// registered with RegisterHandler -- without drawing anything.
+	PollEvents(roots []*RenderElement)
+	// Draw renders one frame of the given trees.
+	Draw(roots []*RenderElement)
+	// Cleanup releases everything Init acquired. Safe to call more than
+	// once, or without a prior Init.
+	Cleanup()
+	// Screenshot captures the most recently drawn frame.
+	Screenshot() (image.Image, error)
+}
+
+// Runner is a Renderer that can drive its own Poll-Layout-Draw loop
+// until the window closes, instead of a caller calling PollEvents/Draw
+// manually every frame.
+type Runner interface {
+	Renderer
+	RunLoop(roots []*RenderElement)
+}
+
+// ProgressFunc reports progress while a batch of textures preloads:
+// loaded out of total images have finished decoding and uploading.
+type ProgressFunc func(loaded, total int)
+
+// TextureLoader is a Renderer whose images are backed by a texture
+// cache a caller can warm up or bound ahead of time, rather than always
+// paying for the first draw's load.
+type TextureLoader interface {
+	PreloadTextures(paths []string, onProgress ProgressFunc) error
+	SetMaxTextureDimension(max int32)
+}
+
+// AsTextureLoader returns r's TextureLoader capability and whether it
+// has one.
+func AsTextureLoader(r Renderer) (TextureLoader, bool) {
+	tl, ok := r.(TextureLoader)
+	return tl, ok
+}
+
+// FontLoader is a Renderer that can register custom and fallback fonts
+// for text rendering.
+type FontLoader interface {
+	LoadCustomFont(name, path string, fontSize int32) error
+	SetFallbackFonts(names []string)
+}
+
+// AsFontLoader returns r's FontLoader capability and whether it has one.
+func AsFontLoader(r Renderer) (FontLoader, bool) {
+	fl, ok := r.(FontLoader)
+	return fl, ok
+}
+
+// VideoController is a Renderer that can control ElemTypeVideo playback.
+// Installing the frame decoder itself is backend-specific (raylib's
+// decodes to an rl.Image) and so isn't part of this interface.
+type VideoController interface {
+	PlayVideo(el *RenderElement)
+	PauseVideo(el *RenderElement)
+	SeekVideo(el *RenderElement, seconds float32)
+}
+
+// AsVideoController returns r's VideoController capability and whether
+// it has one.
+func AsVideoController(r Renderer) (VideoController, bool) {
+	vc, ok := r.(VideoController)
+	return vc, ok
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"image"
+	"testing"
+)
+
+// stubRenderer is a minimal, non-graphical Renderer used to prove that
+// satisfying the core interface doesn't require a real graphics backend.
+type stubRenderer struct {
+	initialized bool
+	drawn       int
+	polled      int
+}
+
+var _ Renderer = (*stubRenderer)(nil)
+
+func (s *stubRenderer) Init(cfg WindowConfig) error {
+	s.initialized = true
+	return nil
+}
+
+func (s *stubRenderer) PollEvents(roots []*RenderElement) {
+	s.polled++
+}
+
+func (s *stubRenderer) Draw(roots []*RenderElement) {
+	s.drawn++
+}
+
+func (s *stubRenderer) Cleanup() {
+	s.initialized = false
+}
+
+func (s *stubRenderer) Screenshot() (image.Image, error) {
+	return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
+}
+
+func TestStubRendererSatisfiesRenderer(t *testing.T) {
+	var r Renderer = &stubRenderer{}
+	if err := r.Init(WindowConfig{Width: 100, Height: 100}); err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	r.PollEvents(nil)
+	r.Draw(nil)
+	if _, err := r.Screenshot(); err != nil {
+		t.Fatalf("Screenshot() returned error: %v", err)
+	}
+	r.Cleanup()
+
+	s := r.(*stubRenderer)
+	if s.polled != 1 || s.drawn != 1 {
+		t.Fatalf("polled = %d, drawn = %d, want 1, 1", s.polled, s.drawn)
+	}
+}
+
+func TestAsTextureLoaderFalseForStub(t *testing.T) {
+	if _, ok := AsTextureLoader(&stubRenderer{}); ok {
+		t.Fatalf("stubRenderer doesn't implement TextureLoader, want ok = false")
+	}
+}
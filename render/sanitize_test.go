@@ -0,0 +1,60 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLayoutSanitizesNonFiniteFields(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width = 100
+	root.Height = 100
+
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child.Width = float32(math.NaN())
+	child.Height = float32(math.Inf(1))
+	root.Children = []*RenderElement{child}
+	child.Parent = root
+
+	errs := Layout([]*RenderElement{root}, 100, 100)
+
+	if child.Width != 0 || child.Height != 0 {
+		t.Fatalf("child bounds = (%v, %v), want both sanitized to 0", child.Width, child.Height)
+	}
+	if len(errs) == 0 {
+		t.Fatalf("expected sanitizeNonFinite to report the elements it sanitized")
+	}
+}
+
+func TestLayoutPaddingExceedingParentProducesNoNaNOrNegativeSize(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width = 10
+	root.Height = 10
+	root.PaddingDim = [4]Dimension{{Value: 255}, {Value: 255}, {Value: 255}, {Value: 255}}
+
+	Layout([]*RenderElement{root}, 10, 10)
+
+	if math.IsNaN(float64(root.Width)) || math.IsNaN(float64(root.Height)) {
+		t.Fatalf("Width/Height = (%v, %v), want finite", root.Width, root.Height)
+	}
+}
+
+func TestLayoutZeroSizeWindowProducesNoNaN(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Children = []*RenderElement{child}
+	child.Parent = root
+
+	Layout([]*RenderElement{root}, 0, 0)
+
+	for _, el := range []*RenderElement{root, child} {
+		if math.IsNaN(float64(el.Width)) || math.IsNaN(float64(el.Height)) {
+			t.Fatalf("element bounds contain NaN: %+v", el)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package render
+
+import (
+	"fmt"
+	"image/png"
+	"os"
+)
+
+// SaveScreenshotPNG captures the renderer's current frame and writes it
+// to path as a PNG. It's a thin convenience wrapper over Renderer.Screenshot
+// for the common case (golden-file tests, bug reports, "save frame" menu
+// items) -- backends that need raw pixel access should call Screenshot directly.
+func SaveScreenshotPNG(r Renderer, path string) error {
+	img, err := r.Screenshot()
+	if err != nil {
+		return fmt.Errorf("render: screenshot: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("render: create %s: %w", path, err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("render: encode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// ScrollIntoView finds el's nearest ancestor krb.ElemTypeScrollable
+// container and adjusts its ScrollOffsetX/Y so el's bounds fall within
+// that container's visible content area, clamped to how far it can
+// actually scroll. It's a no-op if el has no scrollable ancestor, or
+// either el or the container hasn't been laid out yet. Marks the
+// container dirty so the next Layout/Draw picks up the new offset.
+func ScrollIntoView(el *RenderElement) {
+	if el == nil {
+		return
+	}
+	container := nearestScrollable(el.Parent)
+	if container == nil {
+		return
+	}
+	content, ok := ContentBounds(container)
+	if !ok {
+		return
+	}
+	bounds, ok := ElementBounds(el)
+	if !ok {
+		return
+	}
+
+	if bounds.X < content.X {
+		container.ScrollOffsetX -= content.X - bounds.X
+	} else if bounds.X+bounds.Width > content.X+content.Width {
+		container.ScrollOffsetX += (bounds.X + bounds.Width) - (content.X + content.Width)
+	}
+	if bounds.Y < content.Y {
+		container.ScrollOffsetY -= content.Y - bounds.Y
+	} else if bounds.Y+bounds.Height > content.Y+content.Height {
+		container.ScrollOffsetY += (bounds.Y + bounds.Height) - (content.Y + content.Height)
+	}
+
+	container.ScrollOffsetX = clampFloat32(container.ScrollOffsetX, 0, container.ContentWidth-content.Width)
+	container.ScrollOffsetY = clampFloat32(container.ScrollOffsetY, 0, container.ContentHeight-content.Height)
+	container.MarkDirty()
+}
+
+func nearestScrollable(el *RenderElement) *RenderElement {
+	for p := el; p != nil; p = p.Parent {
+		if p.Type == krb.ElemTypeScrollable {
+			return p
+		}
+	}
+	return nil
+}
+
+// wheelScrollStep is how many pixels one unit of InputState.Wheel moves a
+// scrollable container -- matching the size of a single mouse-wheel notch
+// under a typical OS scroll setting.
+const wheelScrollStep = 40
+
+// dispatchWheel routes state.Wheel to the innermost scrollable container
+// under the cursor -- nearestScrollable walking up from hit, the same
+// element hitTestRoots already resolved for this frame, so a scrollable
+// nested inside another only scrolls itself, not its ancestor. Shift held
+// scrolls the cross axis, matching most desktop UIs. It's a no-op if hit
+// has no scrollable ancestor-or-self, or hasn't been laid out yet.
+func (d *InputDispatcher) dispatchWheel(hit *RenderElement, state InputState) {
+	if state.Wheel == 0 {
+		return
+	}
+	container := nearestScrollable(hit)
+	if container == nil {
+		return
+	}
+	content, ok := ContentBounds(container)
+	if !ok {
+		return
+	}
+	delta := state.Wheel * wheelScrollStep
+	if state.Shift {
+		container.ScrollOffsetX = clampFloat32(container.ScrollOffsetX-delta, 0, container.ContentWidth-content.Width)
+	} else {
+		container.ScrollOffsetY = clampFloat32(container.ScrollOffsetY-delta, 0, container.ContentHeight-content.Height)
+	}
+	container.MarkDirty()
+}
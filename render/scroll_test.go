@@ -0,0 +1,195 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func scrollableColumn(childCount int, childHeight, width, height float32) (*RenderElement, []*RenderElement) {
+	doc := &krb.Document{Strings: []string{""}}
+	src := &krb.Element{Type: krb.ElemTypeScrollable, Layout: krb.LayoutDirectionColumn}
+	container := newRenderElement(doc, src)
+	container.Width, container.Height = width, height
+
+	children := make([]*RenderElement, childCount)
+	for i := range children {
+		c := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Index: i})
+		c.Height = childHeight
+		c.Width = width
+		c.Parent = container
+		children[i] = c
+	}
+	container.Children = children
+	return container, children
+}
+
+func TestLayoutComputesContentHeightAndClampsScrollOffset(t *testing.T) {
+	container, _ := scrollableColumn(4, 30, 100, 50)
+	Layout([]*RenderElement{container}, 100, 50)
+
+	if container.ContentHeight != 120 {
+		t.Fatalf("ContentHeight = %v, want 120 (4 children x 30px)", container.ContentHeight)
+	}
+
+	container.ScrollOffsetY = 1000
+	Layout([]*RenderElement{container}, 100, 50)
+	if container.ScrollOffsetY != 70 {
+		t.Fatalf("ScrollOffsetY = %v, want clamped to 70 (ContentHeight 120 - viewport 50)", container.ScrollOffsetY)
+	}
+
+	container.ScrollOffsetY = -10
+	Layout([]*RenderElement{container}, 100, 50)
+	if container.ScrollOffsetY != 0 {
+		t.Fatalf("ScrollOffsetY = %v, want clamped to 0", container.ScrollOffsetY)
+	}
+}
+
+func TestScrollIntoViewBringsBelowFoldElementIntoView(t *testing.T) {
+	container, children := scrollableColumn(4, 30, 100, 50)
+	Layout([]*RenderElement{container}, 100, 50)
+
+	last := children[3]
+	ScrollIntoView(last)
+	Layout([]*RenderElement{container}, 100, 50)
+
+	if container.ScrollOffsetY != 70 {
+		t.Fatalf("ScrollOffsetY = %v, want 70 so the last child's bottom edge is visible", container.ScrollOffsetY)
+	}
+	bounds, ok := ElementBounds(last)
+	if !ok {
+		t.Fatalf("ElementBounds(last) reported not ok")
+	}
+	content, ok := ContentBounds(container)
+	if !ok {
+		t.Fatalf("ContentBounds(container) reported not ok")
+	}
+	if bounds.Y+bounds.Height > content.Y+content.Height+0.001 {
+		t.Fatalf("last child's bottom edge %v still falls outside the visible content area ending at %v", bounds.Y+bounds.Height, content.Y+content.Height)
+	}
+}
+
+func TestScrollIntoViewScrollsBackUpForAboveFoldElement(t *testing.T) {
+	container, children := scrollableColumn(4, 30, 100, 50)
+	container.ScrollOffsetY = 70
+	Layout([]*RenderElement{container}, 100, 50)
+
+	ScrollIntoView(children[0])
+	Layout([]*RenderElement{container}, 100, 50)
+
+	if container.ScrollOffsetY != 0 {
+		t.Fatalf("ScrollOffsetY = %v, want 0 so the first child scrolls back into view", container.ScrollOffsetY)
+	}
+}
+
+// nestedScrollables builds a 100x50 outer scrollable containing a 100x40
+// spacer followed by a 100x20 inner scrollable, itself holding 3 100x20
+// children (60px of content in a 20px viewport). Hovering the spacer sits
+// only inside the outer scrollable; hovering the inner scrollable's
+// children sits inside both.
+func nestedScrollables() (outer, inner *RenderElement, innerChildren []*RenderElement) {
+	doc := &krb.Document{Strings: []string{""}}
+	outer = newRenderElement(doc, &krb.Element{Type: krb.ElemTypeScrollable, Layout: krb.LayoutDirectionColumn})
+	outer.Width, outer.Height = 100, 50
+
+	spacer := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	spacer.Width, spacer.Height = 100, 40
+	spacer.Parent = outer
+
+	inner = newRenderElement(doc, &krb.Element{Type: krb.ElemTypeScrollable, Layout: krb.LayoutDirectionColumn})
+	inner.Width, inner.Height = 100, 20
+	inner.Parent = outer
+
+	innerChildren = make([]*RenderElement, 3)
+	for i := range innerChildren {
+		c := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Index: i})
+		c.Width, c.Height = 100, 20
+		c.Parent = inner
+		innerChildren[i] = c
+	}
+	inner.Children = innerChildren
+	outer.Children = []*RenderElement{spacer, inner}
+	return outer, inner, innerChildren
+}
+
+func TestDispatchWheelScrollsInnermostScrollableUnderCursor(t *testing.T) {
+	outer, inner, _ := nestedScrollables()
+	Layout([]*RenderElement{outer}, 100, 50)
+
+	var d InputDispatcher
+	// (10, 45) falls inside the inner scrollable's first visible child.
+	d.Dispatch([]*RenderElement{outer}, InputState{MouseX: 10, MouseY: 45, Wheel: -1})
+
+	if inner.ScrollOffsetY != wheelScrollStep {
+		t.Fatalf("inner.ScrollOffsetY = %v, want %v", inner.ScrollOffsetY, wheelScrollStep)
+	}
+	if outer.ScrollOffsetY != 0 {
+		t.Fatalf("outer.ScrollOffsetY = %v, want 0 (wheel should not reach past the inner scrollable)", outer.ScrollOffsetY)
+	}
+}
+
+func TestDispatchWheelScrollsOuterWhenCursorIsOutsideInner(t *testing.T) {
+	outer, inner, _ := nestedScrollables()
+	Layout([]*RenderElement{outer}, 100, 50)
+
+	var d InputDispatcher
+	// (10, 10) falls inside the spacer, above the inner scrollable.
+	d.Dispatch([]*RenderElement{outer}, InputState{MouseX: 10, MouseY: 10, Wheel: -1})
+
+	if want := outer.ContentHeight - 50; outer.ScrollOffsetY != want {
+		t.Fatalf("outer.ScrollOffsetY = %v, want %v (clamped to how far outer can scroll)", outer.ScrollOffsetY, want)
+	}
+	if inner.ScrollOffsetY != 0 {
+		t.Fatalf("inner.ScrollOffsetY = %v, want 0 (cursor wasn't over it)", inner.ScrollOffsetY)
+	}
+}
+
+func TestDispatchWheelClampsToContentBounds(t *testing.T) {
+	outer, inner, _ := nestedScrollables()
+	Layout([]*RenderElement{outer}, 100, 50)
+
+	var d InputDispatcher
+	// A wheel delta far larger than the 40px of scrollable content.
+	d.Dispatch([]*RenderElement{outer}, InputState{MouseX: 10, MouseY: 45, Wheel: -100})
+
+	if inner.ScrollOffsetY != inner.ContentHeight-20 {
+		t.Fatalf("inner.ScrollOffsetY = %v, want clamped to %v", inner.ScrollOffsetY, inner.ContentHeight-20)
+	}
+}
+
+func TestInputDispatcherFocusScrollsElementIntoView(t *testing.T) {
+	outer, inner, innerChildren := nestedScrollables()
+	for _, c := range innerChildren {
+		c.Source.Type = krb.ElemTypeButton
+		c.IsInteractive = true
+	}
+	Layout([]*RenderElement{outer}, 100, 50)
+
+	last := innerChildren[2]
+	var d InputDispatcher
+	// Press at the point last currently occupies before it's scrolled
+	// into view (its unclamped layout position, off the bottom of inner's
+	// viewport) to focus it.
+	x, y := last.X+1, last.Y+1
+	d.Dispatch([]*RenderElement{outer}, InputState{MouseX: x, MouseY: y, MouseDown: [3]bool{true}})
+	if d.Focused != last {
+		t.Fatalf("expected last child focused, got %v", d.Focused)
+	}
+	Layout([]*RenderElement{outer}, 100, 50)
+
+	if inner.ScrollOffsetY != inner.ContentHeight-20 {
+		t.Fatalf("inner.ScrollOffsetY = %v, want scrolled to %v so the focused child is visible", inner.ScrollOffsetY, inner.ContentHeight-20)
+	}
+}
+
+func TestScrollIntoViewNoOpWithoutScrollableAncestor(t *testing.T) {
+	doc := &krb.Document{Strings: []string{""}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	child.Parent = root
+	root.Children = []*RenderElement{child}
+	root.Width, root.Height = 100, 100
+	Layout([]*RenderElement{root}, 100, 100)
+
+	ScrollIntoView(child) // must not panic
+}
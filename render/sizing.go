@@ -0,0 +1,81 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// applySizingCustomProperties reads the conventional "fit_content"
+// boolean custom property onto FitContent.
+func applySizingCustomProperties(re *RenderElement) {
+	re.FitContent = false
+	for _, cp := range re.Source.CustomProperties {
+		if re.Doc.String(cp.KeyIndex) == "fit_content" {
+			re.FitContent = len(cp.Value) > 0 && cp.Value[0] != 0
+		}
+	}
+}
+
+// estimatedCharWidth approximates one rune's rendered width in pixels,
+// for sizing a fit_content Text leaf from its own TextContent. This
+// package has no real font metrics -- those live in a backend, e.g.
+// raylib's MeasureTextEx -- so this is deliberately a flat per-rune
+// estimate, good enough to notice that translated text of a different
+// length needs a different width, not a pixel-accurate substitute for
+// one.
+const estimatedCharWidth = 8
+
+// fitContentSize shrinks el to the extent of its in-flow children plus
+// its own padding/gap, on both axes, instead of whatever Width/Height it
+// was otherwise going to carry into layoutFlowChildren. It's the
+// "fit_content" custom property's effect, used for badges, chips and
+// inline buttons that should hug their content horizontally rather than
+// stretch to their parent.
+//
+// It measures children by their current Width/Height, which for leaf
+// elements (Image, ...) is whatever the KRB file declared. A fit_content
+// element whose own children are themselves fit_content and not yet
+// sized by the time this runs won't measure them correctly -- this is a
+// single top-down pass, not a full bottom-up constraint solve.
+//
+// A Text element itself (rather than a container wrapping one) has no
+// children to measure this way, so it's sized directly from its own
+// TextContent via estimatedCharWidth instead -- this is what lets a
+// fit_content Text element's width track its content across a
+// SetTranslations call, since TextContent's length can change even
+// though nothing in the KRB file did.
+func fitContentSize(el *RenderElement) {
+	if el.Type == krb.ElemTypeText {
+		el.Width = float32(len([]rune(el.TextContent)))*estimatedCharWidth + el.Padding[1] + el.Padding[3]
+		return
+	}
+
+	layout := el.EffectiveLayout
+	column := layout&krb.LayoutDirectionMask == krb.LayoutDirectionColumn ||
+		layout&krb.LayoutDirectionMask == krb.LayoutDirectionColRev
+
+	var mainTotal, crossMax float32
+	flowCount := 0
+	for _, c := range el.Children {
+		if c.EffectiveLayout&krb.LayoutAbsoluteBit != 0 {
+			continue
+		}
+		flowCount++
+		main, cross := c.Width, c.Height
+		if column {
+			main, cross = c.Height, c.Width
+		}
+		mainTotal += main
+		if cross > crossMax {
+			crossMax = cross
+		}
+	}
+	if flowCount > 1 {
+		mainTotal += el.Gap * float32(flowCount-1)
+	}
+
+	if column {
+		el.Height = mainTotal + el.Padding[0] + el.Padding[2]
+		el.Width = crossMax + el.Padding[1] + el.Padding[3]
+	} else {
+		el.Width = mainTotal + el.Padding[1] + el.Padding[3]
+		el.Height = crossMax + el.Padding[0] + el.Padding[2]
+	}
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestFitContentCustomProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "fit_content"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeContainer,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Value: []byte{1}},
+		},
+	})
+	if !el.FitContent {
+		t.Fatalf("expected FitContent to be true")
+	}
+}
+
+func TestFitContentShrinksRowContainerToChildren(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	parent.Width, parent.Height = 500, 200
+	parent.FitContent = true
+	parent.GapDim = Dimension{Value: 4}
+
+	icon := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeImage})
+	icon.Width, icon.Height = 16, 16
+	label := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	label.Width, label.Height = 40, 20
+
+	parent.Children = []*RenderElement{icon, label}
+	icon.Parent, label.Parent = parent, parent
+
+	var errs []error
+	layoutElement(parent, &errs)
+
+	wantWidth := float32(16 + 40 + 4)
+	if parent.Width != wantWidth {
+		t.Fatalf("Width = %v, want %v (shrunk to children + gap)", parent.Width, wantWidth)
+	}
+	if parent.Height != 20 {
+		t.Fatalf("Height = %v, want 20 (tallest child)", parent.Height)
+	}
+}
+
+func TestFitContentIgnoresAbsolutelyPositionedChildren(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	parent.Width, parent.Height = 500, 200
+	parent.FitContent = true
+
+	flowChild := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	flowChild.Width, flowChild.Height = 30, 10
+	badge := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Layout: krb.LayoutAbsoluteBit})
+	badge.Width, badge.Height = 300, 300
+
+	parent.Children = []*RenderElement{flowChild, badge}
+	flowChild.Parent, badge.Parent = parent, parent
+
+	var errs []error
+	layoutElement(parent, &errs)
+
+	if parent.Width != 30 || parent.Height != 10 {
+		t.Fatalf("expected size to ignore the absolute child, got (%v, %v)", parent.Width, parent.Height)
+	}
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLetterSpacingAndLineHeightDecodeFromProperties(t *testing.T) {
+	doc := &krb.Document{}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		Properties: []krb.Property{
+			{ID: krb.PropIDLetterSpacing, Type: krb.ValTypeShort, Value: []byte{2, 0}},
+			{ID: krb.PropIDLineHeight, Type: krb.ValTypeShort, Value: []byte{24, 0}},
+		},
+	})
+	if el.LetterSpacing != 2 {
+		t.Fatalf("LetterSpacing = %v, want 2", el.LetterSpacing)
+	}
+	if el.LineHeight != 24 {
+		t.Fatalf("LineHeight = %v, want 24", el.LineHeight)
+	}
+}
+
+func TestLetterSpacingAndLineHeightDefaultToZero(t *testing.T) {
+	doc := &krb.Document{}
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText})
+	if el.LetterSpacing != 0 || el.LineHeight != 0 {
+		t.Fatalf("expected zero defaults, got LetterSpacing=%v LineHeight=%v", el.LetterSpacing, el.LineHeight)
+	}
+}
@@ -0,0 +1,30 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestLayoutCrossStretchFillsParentCrossAxis(t *testing.T) {
+	doc := &krb.Document{}
+	parent := newRenderElement(doc, &krb.Element{
+		Type:   krb.ElemTypeContainer,
+		Layout: krb.LayoutDirectionRow | krb.LayoutCrossStretchBit,
+	})
+	parent.Width, parent.Height = 200, 100
+
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, Width: 20, Height: 20})
+	child.Parent = parent
+	parent.Children = []*RenderElement{child}
+
+	var errs []error
+	layoutFlowChildren(parent, &errs)
+
+	if child.Height != 100 {
+		t.Fatalf("child.Height = %v, want 100 (stretched to fill the row's cross axis)", child.Height)
+	}
+	if child.Y != 0 {
+		t.Fatalf("child.Y = %v, want 0", child.Y)
+	}
+}
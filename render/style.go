@@ -0,0 +1,272 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// resetToBaseDefaults clears the visual fields a style/property pass is
+// responsible for, so re-applying a style never leaves behind values from
+// whatever was previously resolved.
+func resetToBaseDefaults(re *RenderElement) {
+	re.BgColor = Color{}
+	re.FgColor = Color{}
+	re.BorderColor = Color{}
+	re.bgColorSource = colorSource{}
+	re.fgColorSource = colorSource{}
+	re.borderColorSource = colorSource{}
+	re.BorderWidths = [4]float32{}
+	re.BorderColors = [4]Color{}
+	re.BorderStyle = BorderStyleSolid
+	re.Padding = [4]float32{}
+	re.PaddingDim = [4]Dimension{}
+	re.MinWidthDim = Dimension{}
+	re.MaxWidthDim = Dimension{}
+	re.MinHeightDim = Dimension{}
+	re.MaxHeightDim = Dimension{}
+	re.Gap = 0
+	re.GapDim = Dimension{}
+	re.FlexBasisDim = Dimension{}
+	re.GrowFactor = 0
+	re.TextContent = ""
+	re.TextKey = ""
+	re.ImagePath = ""
+	re.ImageTint = Color{255, 255, 255, 255}
+	re.imageTintSource = colorSource{}
+	re.ImageFlipH = false
+	re.ImageFlipV = false
+	re.TintFromFg = false
+	re.Grayscale = false
+	re.Opacity = 1
+	re.ObjectFit = ObjectFitFill
+	re.FrameCount = 0
+	re.SpriteCols = 0
+	re.SpriteRows = 0
+	re.FPS = 0
+	re.CurrentFrame = 0
+	re.frameElapsed = 0
+	re.CustomData = nil
+	re.CustomDataBlobs = nil
+	re.ClipToParent = false
+	re.Ellipsis = false
+	re.VerticalAlign = VerticalAlignMiddle
+	re.Decoration = 0
+	re.LetterSpacing = 0
+	re.LineHeight = 0
+	re.TextShadowOffsetX, re.TextShadowOffsetY = 0, 0
+	re.TextShadowColor = Color{}
+	re.TextOutlineColor = Color{}
+	re.IsVisible = true
+	re.EffectiveVisible = true
+	re.EffectiveLayout = re.Source.Layout
+	re.Cursor = CursorDefault
+	re.Tooltip = ""
+	re.TooltipKey = ""
+	re.HitPadding = 0
+	re.InheritBackground = false
+}
+
+// applyProperties decodes a list of standard properties onto re. It is
+// used for both style properties and an element's own direct properties,
+// with direct properties applied last so they win ties.
+func applyProperties(re *RenderElement, props []krb.Property) {
+	extended := re.Doc.Header.HasFlag(krb.FlagExtendedColor)
+	for _, p := range props {
+		switch p.ID {
+		case krb.PropIDBackgroundColor:
+			re.BgColor, re.bgColorSource = decodeColorRef(p.Value, extended)
+		case krb.PropIDForegroundColor:
+			re.FgColor, re.fgColorSource = decodeColorRef(p.Value, extended)
+		case krb.PropIDBorderColor:
+			re.BorderColor, re.borderColorSource = decodeColorRef(p.Value, extended)
+			re.BorderColors = [4]Color{re.BorderColor, re.BorderColor, re.BorderColor, re.BorderColor}
+		case krb.PropIDBorderColorTop:
+			re.BorderColors[0], _ = decodeColorRef(p.Value, extended)
+		case krb.PropIDBorderColorRight:
+			re.BorderColors[1], _ = decodeColorRef(p.Value, extended)
+		case krb.PropIDBorderColorBottom:
+			re.BorderColors[2], _ = decodeColorRef(p.Value, extended)
+		case krb.PropIDBorderColorLeft:
+			re.BorderColors[3], _ = decodeColorRef(p.Value, extended)
+		case krb.PropIDBorderStyle:
+			if len(p.Value) > 0 {
+				re.BorderStyle = BorderStyle(p.Value[0])
+			}
+		case krb.PropIDTextContent:
+			if len(p.Value) > 0 {
+				re.TextKey = re.Doc.String(p.Value[0])
+				re.TextContent = activeTranslations.resolve(re.TextKey)
+			}
+		case krb.PropIDCustomDataBlob:
+			blob := append([]byte(nil), p.Value...)
+			re.CustomData = blob
+			re.CustomDataBlobs = append(re.CustomDataBlobs, blob)
+		case krb.PropIDTextDecoration:
+			if len(p.Value) > 0 {
+				re.Decoration = TextDecoration(p.Value[0])
+			}
+		case krb.PropIDLetterSpacing:
+			if len(p.Value) >= 2 {
+				re.LetterSpacing = float32(uint16(p.Value[0]) | uint16(p.Value[1])<<8)
+			}
+		case krb.PropIDLineHeight:
+			if len(p.Value) >= 2 {
+				re.LineHeight = float32(uint16(p.Value[0]) | uint16(p.Value[1])<<8)
+			}
+		case krb.PropIDVisibility:
+			if len(p.Value) > 0 {
+				re.IsVisible = p.Value[0] != 0
+			}
+		case krb.PropIDOpacity:
+			re.Opacity = krb.DecodePercentage(p.Value)
+		case krb.PropIDCursor:
+			if len(p.Value) > 0 {
+				re.Cursor = Cursor(p.Value[0])
+			}
+		case krb.PropIDTooltip:
+			if len(p.Value) > 0 {
+				re.TooltipKey = re.Doc.String(p.Value[0])
+				re.Tooltip = activeTranslations.resolve(re.TooltipKey)
+			}
+		case krb.PropIDObjectFit:
+			if len(p.Value) > 0 {
+				re.ObjectFit = ObjectFit(p.Value[0])
+			}
+		case krb.PropIDSpriteAnimation:
+			if len(p.Value) >= 5 {
+				re.FrameCount = int(p.Value[0])
+				re.SpriteCols = int(p.Value[1])
+				re.SpriteRows = int(p.Value[2])
+				re.FPS = float32(uint16(p.Value[3]) | uint16(p.Value[4])<<8)
+			}
+		case krb.PropIDImageSource:
+			if len(p.Value) > 0 {
+				if res, ok := re.Doc.Resource(p.Value[0]); ok {
+					re.ImagePath = re.Doc.String(res.NameIndex)
+				}
+			}
+		case krb.PropIDBorderWidth:
+			if p.Type == krb.ValTypeEdgeInsets {
+				re.BorderWidths = krb.DecodeEdgeInsets(p.Value)
+			} else if len(p.Value) > 0 {
+				w := float32(p.Value[0])
+				re.BorderWidths = [4]float32{w, w, w, w}
+			}
+		case krb.PropIDPadding:
+			if p.Type == krb.ValTypePercentage {
+				pct := Dimension{Value: krb.DecodePercentage(p.Value), IsPercent: true}
+				re.PaddingDim = [4]Dimension{pct, pct, pct, pct}
+			} else {
+				insets := krb.DecodeEdgeInsets(p.Value)
+				re.PaddingDim = [4]Dimension{{Value: insets[0]}, {Value: insets[1]}, {Value: insets[2]}, {Value: insets[3]}}
+			}
+			re.Padding = resolvePaddingDims(re.PaddingDim, re.Width)
+		case krb.PropIDMinWidth:
+			re.MinWidthDim = decodeSizeDimension(p)
+		case krb.PropIDMaxWidth:
+			re.MaxWidthDim = decodeSizeDimension(p)
+		case krb.PropIDMinHeight:
+			re.MinHeightDim = decodeSizeDimension(p)
+		case krb.PropIDMaxHeight:
+			re.MaxHeightDim = decodeSizeDimension(p)
+		case krb.PropIDGap:
+			if p.Type == krb.ValTypePercentage {
+				re.GapDim = Dimension{Value: krb.DecodePercentage(p.Value), IsPercent: true}
+			} else if len(p.Value) >= 2 {
+				re.GapDim = Dimension{Value: float32(uint16(p.Value[0]) | uint16(p.Value[1])<<8)}
+			}
+			re.Gap = re.GapDim.Resolve(mainAxisSize(re))
+		case krb.PropIDFlexBasis:
+			re.FlexBasisDim = decodeSizeDimension(p)
+		case krb.PropIDGrowFactor:
+			if len(p.Value) > 0 {
+				re.GrowFactor = float32(p.Value[0])
+			}
+		case krb.PropIDTextShadow:
+			if len(p.Value) >= 3 {
+				re.TextShadowOffsetX = float32(int8(p.Value[0]))
+				re.TextShadowOffsetY = float32(int8(p.Value[1]))
+				re.TextShadowColor, _ = decodeColorRef(p.Value[2:], extended)
+			}
+		case krb.PropIDTextOutlineColor:
+			re.TextOutlineColor, _ = decodeColorRef(p.Value, extended)
+		}
+	}
+}
+
+// decodeSizeDimension decodes a min/max width/height property, which
+// uses the same percentage-or-pixels encoding as Gap.
+func decodeSizeDimension(p krb.Property) Dimension {
+	if p.Type == krb.ValTypePercentage {
+		return Dimension{Value: krb.DecodePercentage(p.Value), IsPercent: true}
+	}
+	if len(p.Value) >= 2 {
+		return Dimension{Value: float32(uint16(p.Value[0]) | uint16(p.Value[1])<<8)}
+	}
+	return Dimension{}
+}
+
+// applyStylePropertiesToElement resets re to its base defaults, applies
+// the named style's properties (if styleID is non-zero and resolves to a
+// known style), then reapplies re.Source's own direct properties so they
+// always take precedence over the style.
+func applyStylePropertiesToElement(re *RenderElement, styleID uint8) {
+	resetToBaseDefaults(re)
+	if styleID != 0 {
+		if style, ok := lookupStyle(re.Doc, styleID); ok {
+			applyProperties(re, style.Properties)
+		}
+	}
+	applyProperties(re, re.Source.Properties)
+	resolveDefaultBorderWidths(re)
+	applyCustomProperties(re)
+	applyInteractiveCustomProperties(re)
+	applyImageCustomProperties(re)
+	applyClipCustomProperties(re)
+	applyTextCustomProperties(re)
+	applyLocaleCustomProperties(re)
+	applyAnchorCustomProperties(re)
+	applySizingCustomProperties(re)
+	applyBreakpointCustomProperties(re)
+	applyHitPaddingCustomProperty(re)
+	applyInheritBackgroundCustomProperty(re)
+	re.StyleID = styleID
+}
+
+func lookupStyle(doc *krb.Document, id uint8) (krb.Style, bool) {
+	for _, s := range doc.Styles {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return krb.Style{}, false
+}
+
+// SetStyle re-styles an already-built element at runtime, e.g. to
+// implement theme switches or selected/pressed visual states. It resets
+// the element to its base defaults, reapplies the named style plus the
+// element's own direct properties (which always win), re-resolves text
+// and image content, and marks the element dirty so the next layout pass
+// picks up any size-affecting changes (padding, border width, ...).
+func SetStyle(el *RenderElement, styleID uint8) {
+	applyStylePropertiesToElement(el, styleID)
+	el.MarkDirty()
+}
+
+// ResetStyle reverts el to the style declared for it in the KRB file,
+// undoing any SetStyle calls made since it was built.
+func ResetStyle(el *RenderElement) {
+	SetStyle(el, el.Source.StyleID)
+}
+
+// MarkDirty flags el (and its ancestors, since a child's size change can
+// affect parent layout) as needing relayout, and bumps treeVersion along
+// the same chain so a cached RenderTree result invalidates too. If
+// damage tracking is enabled (see EnableDamageTracking), it also records
+// el's current bounds as the pending damage rect's "before" half.
+func (el *RenderElement) MarkDirty() {
+	if activeDamage != nil {
+		activeDamage.noteDirty(el)
+	}
+	for e := el; e != nil; e = e.Parent {
+		e.Dirty = true
+		e.treeVersion++
+	}
+}
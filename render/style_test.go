@@ -0,0 +1,68 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestSetStyleAppliesAndResetStyleReverts(t *testing.T) {
+	doc := &krb.Document{
+		Strings: []string{""},
+		Styles: []krb.Style{
+			{ID: 1, Properties: []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{1}}}},
+			{ID: 2, Properties: []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{9}}}},
+		},
+	}
+	src := &krb.Element{Type: krb.ElemTypeButton, StyleID: 1}
+	btn := newRenderElement(doc, src)
+
+	if btn.BgColor != Palette[1] {
+		t.Fatalf("expected base style color %v, got %v", Palette[1], btn.BgColor)
+	}
+
+	SetStyle(btn, 2)
+	if btn.BgColor != Palette[9] {
+		t.Fatalf("expected swapped style color %v, got %v", Palette[9], btn.BgColor)
+	}
+	if !btn.Dirty {
+		t.Fatalf("SetStyle did not mark the element dirty")
+	}
+
+	ResetStyle(btn)
+	if btn.BgColor != Palette[1] {
+		t.Fatalf("ResetStyle did not revert to the KRB-declared style, got %v", btn.BgColor)
+	}
+}
+
+func TestVisibilityPropertyDecodesAndDefaultsToVisible(t *testing.T) {
+	doc := &krb.Document{}
+	visible := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	if !visible.IsVisible {
+		t.Fatalf("expected an element with no Visibility property to default to visible")
+	}
+
+	hidden := newRenderElement(doc, &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDVisibility, Type: krb.ValTypeByte, Value: []byte{0}}},
+	})
+	if hidden.IsVisible {
+		t.Fatalf("expected PropIDVisibility=0 to decode to IsVisible = false")
+	}
+}
+
+func TestOpacityPropertyDecodesAndDefaultsToFullyOpaque(t *testing.T) {
+	doc := &krb.Document{}
+	opaque := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	if opaque.Opacity != 1 {
+		t.Fatalf("expected an element with no Opacity property to default to 1, got %v", opaque.Opacity)
+	}
+
+	half := newRenderElement(doc, &krb.Element{
+		Type:       krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDOpacity, Type: krb.ValTypePercentage, Value: []byte{128, 0}}},
+	})
+	if half.Opacity != 0.5 {
+		t.Fatalf("expected PropIDOpacity value 128 to decode to 0.5, got %v", half.Opacity)
+	}
+}
@@ -0,0 +1,183 @@
+package render
+
+// TextDecoration is a bitmask of decorations drawn alongside an element's
+// text, decoded from krb.PropIDTextDecoration.
+type TextDecoration uint8
+
+const (
+	TextDecorationUnderline TextDecoration = 1 << iota
+	TextDecorationStrikethrough
+
+	// TextDecorationShadow enables a drop shadow behind text, drawn as a
+	// second pass offset by TextShadowOffsetX/Y in TextShadowColor before
+	// the normal pass -- for readability over a busy background like an
+	// image. See PropIDTextShadow.
+	TextDecorationShadow
+
+	// TextDecorationOutline enables an outline ring around text, drawn as
+	// several passes offset around the glyph positions in
+	// TextOutlineColor before the normal pass -- an approximation
+	// acceptable without SDF font rendering. See PropIDTextOutlineColor.
+	TextDecorationOutline
+)
+
+// TextOutlineOffsets are the 8 surrounding pixel offsets a backend draws
+// an outline ring with -- one text draw per offset, all in
+// TextOutlineColor, before the normal pass.
+var TextOutlineOffsets = [8][2]float32{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0} /*      */, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+// VerticalAlign positions a text element's line within its own box along
+// the cross axis, decoded from the conventional "vertical_align" custom
+// property ("top", "middle", "bottom", or "baseline").
+type VerticalAlign uint8
+
+const (
+	// VerticalAlignMiddle centers the line in the box. It's the zero
+	// value, matching the implicit centering behavior text elements had
+	// before this property existed.
+	VerticalAlignMiddle VerticalAlign = iota
+	VerticalAlignTop
+	VerticalAlignBottom
+
+	// VerticalAlignBaseline aligns the text's baseline rather than
+	// centering its whole glyph box. True baseline placement needs a
+	// font's ascent/descent metrics, which no backend in this repo
+	// currently exposes -- see TextVerticalOffset -- so until one does,
+	// it resolves the same as VerticalAlignTop.
+	VerticalAlignBaseline
+)
+
+// parseVerticalAlign maps the "vertical_align" custom property's string
+// value to a VerticalAlign, defaulting to VerticalAlignMiddle for an
+// empty or unrecognized value.
+func parseVerticalAlign(s string) VerticalAlign {
+	switch s {
+	case "top":
+		return VerticalAlignTop
+	case "bottom":
+		return VerticalAlignBottom
+	case "baseline":
+		return VerticalAlignBaseline
+	default:
+		return VerticalAlignMiddle
+	}
+}
+
+// TextVerticalOffset returns the distance from the top of a
+// containerHeight-tall box to where a lineHeight-tall line should be
+// drawn, for align. It's deliberately backend-agnostic: accurate
+// baseline placement needs a font's ascent/descent, which isn't
+// available without a loaded font, so VerticalAlignBaseline currently
+// resolves the same as VerticalAlignTop. A backend with real font
+// metrics should compute its own baseline offset instead of relying on
+// this function for VerticalAlignBaseline.
+func TextVerticalOffset(align VerticalAlign, containerHeight, lineHeight float32) float32 {
+	switch align {
+	case VerticalAlignTop, VerticalAlignBaseline:
+		return 0
+	case VerticalAlignBottom:
+		return containerHeight - lineHeight
+	default:
+		return (containerHeight - lineHeight) / 2
+	}
+}
+
+// applyTextCustomProperties reads the conventional "text_overflow",
+// "selectable", and "vertical_align" custom properties. Truncation
+// itself lives in TruncateWithEllipsis rather than here, since actually
+// measuring text needs a backend's font metrics.
+func applyTextCustomProperties(re *RenderElement) {
+	for _, cp := range re.Source.CustomProperties {
+		switch re.Doc.String(cp.KeyIndex) {
+		case "text_overflow":
+			re.Ellipsis = customStringValue(re.Doc, cp) == "ellipsis"
+		case "selectable":
+			re.Selectable = customStringValue(re.Doc, cp) == "true"
+		case "vertical_align":
+			re.VerticalAlign = parseVerticalAlign(customStringValue(re.Doc, cp))
+		}
+	}
+}
+
+// CharIndexAtX returns the rune index into text of the character
+// boundary nearest x, as measured by measure (the cumulative pixel width
+// of a string prefix). It's how drag-selection on a Selectable element
+// turns a pointer position into a selection endpoint; measure is
+// supplied by the caller since only a backend knows its own font
+// metrics, the same split TruncateWithEllipsis uses.
+func CharIndexAtX(text string, x float32, measure func(string) float32) int {
+	runes := []rune(text)
+	if x <= 0 || len(runes) == 0 {
+		return 0
+	}
+	prevWidth := float32(0)
+	for i := 1; i <= len(runes); i++ {
+		width := measure(string(runes[:i]))
+		if x < (prevWidth+width)/2 {
+			return i - 1
+		}
+		prevWidth = width
+	}
+	return len(runes)
+}
+
+// SelectedText returns the substring of el.TextContent currently
+// highlighted, honoring SelectionStart/End in either order. It's empty
+// when there's no selection.
+func SelectedText(el *RenderElement) string {
+	lo, hi := el.SelectionStart, el.SelectionEnd
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	runes := []rune(el.TextContent)
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > len(runes) {
+		hi = len(runes)
+	}
+	if lo >= hi {
+		return ""
+	}
+	return string(runes[lo:hi])
+}
+
+// ClearSelection drops any active selection on el.
+func ClearSelection(el *RenderElement) {
+	el.SelectionStart, el.SelectionEnd = 0, 0
+}
+
+// TruncateWithEllipsis returns text unchanged if measure(text) already
+// fits within maxWidth; otherwise it returns the longest prefix of text,
+// followed by "...", whose measured width still fits. measure is
+// supplied by the caller rather than assumed, since only a backend knows
+// its own font metrics (see RenderElement.Ellipsis, set from the
+// "text_overflow: ellipsis" custom property).
+func TruncateWithEllipsis(text string, maxWidth float32, measure func(string) float32) string {
+	const ellipsis = "..."
+	if maxWidth <= 0 || measure(text) <= maxWidth {
+		return text
+	}
+	if measure(ellipsis) > maxWidth {
+		return ellipsis
+	}
+
+	runes := []rune(text)
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if measure(string(runes[:mid])+ellipsis) <= maxWidth {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	if lo == 0 {
+		return ellipsis
+	}
+	return string(runes[:lo]) + ellipsis
+}
@@ -0,0 +1,156 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestTextOverflowCustomProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "text_overflow", "ellipsis"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	})
+	if !el.Ellipsis {
+		t.Fatalf("expected Ellipsis to be true")
+	}
+}
+
+// widthPerRune is a trivial monospace stand-in for a real font's
+// MeasureText, so these tests don't depend on a backend.
+func widthPerRune(s string) float32 {
+	return float32(len([]rune(s)))
+}
+
+func TestTruncateWithEllipsisLeavesFittingTextAlone(t *testing.T) {
+	got := TruncateWithEllipsis("hello", 10, widthPerRune)
+	if got != "hello" {
+		t.Fatalf("got %q, want unchanged %q", got, "hello")
+	}
+}
+
+func TestTruncateWithEllipsisShortensOverflowingText(t *testing.T) {
+	got := TruncateWithEllipsis("hello world", 8, widthPerRune)
+	if widthPerRune(got) > 8 {
+		t.Fatalf("got %q (width %v), want it to fit within 8", got, widthPerRune(got))
+	}
+	if got[len(got)-3:] != "..." {
+		t.Fatalf("got %q, want it to end with an ellipsis", got)
+	}
+}
+
+func TestTruncateWithEllipsisFallsBackToBareEllipsisWhenNothingFits(t *testing.T) {
+	got := TruncateWithEllipsis("hello", 2, widthPerRune)
+	if got != "..." {
+		t.Fatalf("got %q, want bare ellipsis when even that doesn't fit", got)
+	}
+}
+
+func TestSelectableCustomProperty(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "selectable", "true"}}
+	el := newRenderElement(doc, &krb.Element{
+		Type: krb.ElemTypeText,
+		CustomProperties: []krb.CustomProperty{
+			{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+		},
+	})
+	if !el.Selectable {
+		t.Fatalf("expected Selectable to be true")
+	}
+}
+
+// widthPerRune (above) stands in for a monospace font: each rune is
+// exactly 1px wide, so CharIndexAtX's expected boundaries are easy to
+// state precisely.
+func TestCharIndexAtXFindsNearestBoundary(t *testing.T) {
+	tests := []struct {
+		x    float32
+		want int
+	}{
+		{-5, 0},  // before the text entirely
+		{0, 0},   // at the very start
+		{0.4, 0}, // closer to the boundary before 'h' than after
+		{0.6, 1}, // closer to the boundary after 'h'
+		{2.5, 3}, // exactly between 2 and 3 -> the later boundary wins
+		{100, 5}, // past the end of "hello"
+	}
+	for _, tt := range tests {
+		if got := CharIndexAtX("hello", tt.x, widthPerRune); got != tt.want {
+			t.Fatalf("CharIndexAtX(%q, %v) = %d, want %d", "hello", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestSelectedTextHonorsEitherDragDirection(t *testing.T) {
+	el := &RenderElement{TextContent: "hello world"}
+	el.SelectionStart, el.SelectionEnd = 6, 11
+	if got := SelectedText(el); got != "world" {
+		t.Fatalf("got %q, want %q", got, "world")
+	}
+
+	el.SelectionStart, el.SelectionEnd = 11, 6
+	if got := SelectedText(el); got != "world" {
+		t.Fatalf("dragging backwards: got %q, want %q", got, "world")
+	}
+}
+
+func TestSelectedTextEmptyWhenNoSelection(t *testing.T) {
+	el := &RenderElement{TextContent: "hello"}
+	if got := SelectedText(el); got != "" {
+		t.Fatalf("got %q, want empty string for a zero-width selection", got)
+	}
+}
+
+func TestClearSelectionResetsBothEndpoints(t *testing.T) {
+	el := &RenderElement{TextContent: "hello", SelectionStart: 1, SelectionEnd: 4}
+	ClearSelection(el)
+	if el.SelectionStart != 0 || el.SelectionEnd != 0 {
+		t.Fatalf("ClearSelection left (%d,%d), want (0,0)", el.SelectionStart, el.SelectionEnd)
+	}
+}
+
+func TestVerticalAlignCustomProperty(t *testing.T) {
+	tests := []struct {
+		value string
+		want  VerticalAlign
+	}{
+		{"top", VerticalAlignTop},
+		{"middle", VerticalAlignMiddle},
+		{"bottom", VerticalAlignBottom},
+		{"baseline", VerticalAlignBaseline},
+		{"nonsense", VerticalAlignMiddle},
+	}
+	for _, tt := range tests {
+		doc := &krb.Document{Strings: []string{"", "vertical_align", tt.value}}
+		el := newRenderElement(doc, &krb.Element{
+			Type: krb.ElemTypeText,
+			CustomProperties: []krb.CustomProperty{
+				{KeyIndex: 1, Type: krb.ValTypeString, Value: []byte{2}},
+			},
+		})
+		if el.VerticalAlign != tt.want {
+			t.Errorf("vertical_align %q: VerticalAlign = %v, want %v", tt.value, el.VerticalAlign, tt.want)
+		}
+	}
+}
+
+func TestTextVerticalOffset(t *testing.T) {
+	tests := []struct {
+		name  string
+		align VerticalAlign
+		want  float32
+	}{
+		{"top", VerticalAlignTop, 0},
+		{"middle", VerticalAlignMiddle, 15},
+		{"bottom", VerticalAlignBottom, 30},
+		{"baseline falls back to top", VerticalAlignBaseline, 0},
+	}
+	for _, tt := range tests {
+		if got := TextVerticalOffset(tt.align, 50, 20); got != tt.want {
+			t.Errorf("%s: TextVerticalOffset = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package render
+
+import "fmt"
+
+// ThemeTokenBase is the first reserved 1-byte palette index. Indices in
+// [ThemeTokenBase, 256) denote a theme color token rather than a literal
+// Palette entry, so a style can say "use the theme's accent color" instead
+// of baking in a fixed RGBA value.
+const ThemeTokenBase uint8 = 240
+
+// Theme is a named set of color tokens. Styles reference a token by its
+// palette index (ThemeTokenBase + token offset); SetTheme recolors every
+// element field that resolved through a token, leaving elements with
+// direct (non-token) colors untouched.
+type Theme struct {
+	Name   string
+	Tokens [256 - int(ThemeTokenBase)]Color
+}
+
+var (
+	themeRegistry = map[string]Theme{}
+	activeTheme   Theme
+	activeMode    ThemeMode
+)
+
+// ThemeMode names one of the two conventional theme variants an app
+// registers. Apps that only need light/dark switching (rather than
+// arbitrary named themes) can use SetThemeMode instead of calling
+// SetTheme with a string literal.
+type ThemeMode string
+
+const (
+	ThemeModeLight ThemeMode = "light"
+	ThemeModeDark  ThemeMode = "dark"
+)
+
+// SetThemeMode is SetTheme for the common light/dark case: it activates
+// whichever theme was registered under mode's name ("light" or "dark")
+// and remembers the active mode for CurrentThemeMode.
+func SetThemeMode(roots []*RenderElement, mode ThemeMode) error {
+	if err := SetTheme(roots, string(mode)); err != nil {
+		return err
+	}
+	activeMode = mode
+	return nil
+}
+
+// CurrentThemeMode returns the mode last activated via SetThemeMode, or
+// "" if SetThemeMode has never been called (including when SetTheme was
+// called directly with a theme name other than "light"/"dark").
+func CurrentThemeMode() ThemeMode {
+	return activeMode
+}
+
+// RegisterTheme makes a theme available to SetTheme by name. Registering
+// a theme with a name that already exists replaces it.
+func RegisterTheme(t Theme) {
+	themeRegistry[t.Name] = t
+}
+
+func resolveThemeToken(paletteIndex uint8) Color {
+	offset := int(paletteIndex) - int(ThemeTokenBase)
+	if offset < 0 || offset >= len(activeTheme.Tokens) {
+		return Color{}
+	}
+	return activeTheme.Tokens[offset]
+}
+
+// SetTheme activates the named theme and recolors every element in the
+// given trees that references a theme token, then marks each recolored
+// element dirty so the next layout/draw pass picks it up. Elements whose
+// colors were set directly (not through a token) are left as-is.
+func SetTheme(roots []*RenderElement, name string) error {
+	t, ok := themeRegistry[name]
+	if !ok {
+		return fmt.Errorf("render: unknown theme %q", name)
+	}
+	activeTheme = t
+	for _, root := range roots {
+		recolorTree(root)
+	}
+	return nil
+}
+
+func recolorTree(el *RenderElement) {
+	changed := false
+	if el.bgColorSource.isToken {
+		el.BgColor = resolveThemeToken(el.bgColorSource.token)
+		changed = true
+	}
+	if el.fgColorSource.isToken {
+		el.FgColor = resolveThemeToken(el.fgColorSource.token)
+		changed = true
+	}
+	if el.borderColorSource.isToken {
+		el.BorderColor = resolveThemeToken(el.borderColorSource.token)
+		changed = true
+	}
+	if el.imageTintSource.isToken {
+		el.ImageTint = resolveThemeToken(el.imageTintSource.token)
+		changed = true
+	}
+	if changed {
+		el.Dirty = true
+	}
+	for _, c := range el.Children {
+		recolorTree(c)
+	}
+}
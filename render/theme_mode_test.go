@@ -0,0 +1,29 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestSetThemeModeTracksCurrentMode(t *testing.T) {
+	RegisterTheme(Theme{Name: "light"})
+	RegisterTheme(Theme{Name: "dark"})
+
+	doc := &krb.Document{Strings: []string{""}}
+	el := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+
+	if err := SetThemeMode([]*RenderElement{el}, ThemeModeDark); err != nil {
+		t.Fatalf("SetThemeMode(dark): %v", err)
+	}
+	if CurrentThemeMode() != ThemeModeDark {
+		t.Fatalf("CurrentThemeMode() = %q, want %q", CurrentThemeMode(), ThemeModeDark)
+	}
+
+	if err := SetThemeMode([]*RenderElement{el}, ThemeModeLight); err != nil {
+		t.Fatalf("SetThemeMode(light): %v", err)
+	}
+	if CurrentThemeMode() != ThemeModeLight {
+		t.Fatalf("CurrentThemeMode() = %q, want %q", CurrentThemeMode(), ThemeModeLight)
+	}
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestSetThemeRecolorsTokensOnly(t *testing.T) {
+	RegisterTheme(Theme{Name: "light", Tokens: func() (toks [256 - int(ThemeTokenBase)]Color) {
+		toks[0] = Color{R: 255, G: 255, B: 255, A: 255} // accent token 0
+		return
+	}()})
+	RegisterTheme(Theme{Name: "dark", Tokens: func() (toks [256 - int(ThemeTokenBase)]Color) {
+		toks[0] = Color{R: 10, G: 10, B: 10, A: 255} // accent token 0
+		return
+	}()})
+
+	doc := &krb.Document{Strings: []string{""}}
+	themed := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{ThemeTokenBase}}}})
+	direct := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer,
+		Properties: []krb.Property{{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{1}}}})
+	themed.Children = []*RenderElement{direct}
+	direct.Parent = themed
+
+	if err := SetTheme([]*RenderElement{themed}, "light"); err != nil {
+		t.Fatalf("SetTheme(light): %v", err)
+	}
+	if themed.BgColor != (Color{255, 255, 255, 255}) {
+		t.Fatalf("token element not recolored for light theme: %v", themed.BgColor)
+	}
+	directBefore := direct.BgColor
+
+	if err := SetTheme([]*RenderElement{themed}, "dark"); err != nil {
+		t.Fatalf("SetTheme(dark): %v", err)
+	}
+	if themed.BgColor != (Color{10, 10, 10, 255}) {
+		t.Fatalf("token element not recolored for dark theme: %v", themed.BgColor)
+	}
+	if direct.BgColor != directBefore {
+		t.Fatalf("direct (non-token) color changed across theme switch: %v -> %v", directBefore, direct.BgColor)
+	}
+}
@@ -0,0 +1,160 @@
+package render
+
+import "github.com/waozixyz/kryon/krb"
+
+// DefaultToastDuration is how long a toast holds at full opacity (after
+// fading in, before fading out) when ToastOptions.Duration is 0.
+const DefaultToastDuration float32 = 3
+
+// toastFadeSeconds is how long a toast spends fading in and fading out.
+const toastFadeSeconds float32 = 0.25
+
+// toastSpacing separates stacked concurrent toasts sharing a Position.
+const toastSpacing float32 = 8
+
+// ToastOptions configures ShowToast. Position reuses the Anchor* corner
+// and center constants, since a toast is exactly an absolutely
+// positioned, runtime-created element.
+type ToastOptions struct {
+	Duration float32
+	Position string
+	BgColor  Color
+}
+
+func (o ToastOptions) withDefaults() ToastOptions {
+	if o.Duration <= 0 {
+		o.Duration = DefaultToastDuration
+	}
+	if o.Position == "" {
+		o.Position = AnchorBottomRight
+	}
+	if o.BgColor == (Color{}) {
+		o.BgColor = Color{R: 32, G: 32, B: 32, A: 230}
+	}
+	return o
+}
+
+type toastPhase uint8
+
+const (
+	toastFadingIn toastPhase = iota
+	toastHolding
+	toastFadingOut
+	toastDone
+)
+
+// Toast is one active ShowToast element, owned by a ToastManager.
+type Toast struct {
+	Element *RenderElement
+	opts    ToastOptions
+	phase   toastPhase
+	elapsed float32
+	alpha   uint8
+}
+
+// ToastManager creates, stacks, animates and tears down the transient
+// elements ShowToast needs -- none of which ever exist in the KRB file
+// they float above. Construct one per window root.
+type ToastManager struct {
+	root   *RenderElement
+	toasts []*Toast
+}
+
+// NewToastManager creates a manager whose toasts are appended as children
+// of root, so they're laid out, hit-tested and drawn alongside the rest
+// of root's tree without root.Source ever having declared them.
+func NewToastManager(root *RenderElement) *ToastManager {
+	return &ToastManager{root: root}
+}
+
+// ShowToast creates a toast element showing text, fades it in, holds it
+// for opts.Duration, fades it out, then removes it -- driven by Update,
+// which the backend's frame loop must call with each frame's delta time.
+// Concurrent toasts sharing a Position stack outward from their shared
+// edge/center with toastSpacing between them. Clicking a toast dismisses
+// it immediately; toasts never intercept clicks elsewhere, since HitTest
+// only ever hits the small rect each toast actually covers.
+func (tm *ToastManager) ShowToast(text string, opts ToastOptions) *Toast {
+	opts = opts.withDefaults()
+	el := newRenderElement(tm.root.Doc, &krb.Element{
+		Type:   krb.ElemTypeText,
+		Layout: krb.LayoutAbsoluteBit,
+		Width:  220,
+		Height: 36,
+	})
+	el.TextContent = text
+	el.BgColor = opts.BgColor
+	el.BgColor.A = 0
+	el.Anchor = opts.Position
+
+	toast := &Toast{Element: el, opts: opts}
+	el.OnClick = func(evt *Event) {
+		tm.dismiss(toast)
+		evt.StopPropagation()
+	}
+
+	tm.root.AppendChild(el)
+	tm.toasts = append(tm.toasts, toast)
+	tm.restack()
+	return toast
+}
+
+// Update advances every active toast's fade/hold timing by dt seconds,
+// removing any that have finished fading out.
+func (tm *ToastManager) Update(dt float32) {
+	live := tm.toasts[:0]
+	removed := false
+	for _, t := range tm.toasts {
+		t.elapsed += dt
+		switch t.phase {
+		case toastFadingIn:
+			if frac := t.elapsed / toastFadeSeconds; frac >= 1 {
+				t.alpha = t.opts.BgColor.A
+				t.phase = toastHolding
+				t.elapsed = 0
+			} else {
+				t.alpha = uint8(frac * float32(t.opts.BgColor.A))
+			}
+		case toastHolding:
+			if t.elapsed >= t.opts.Duration {
+				t.phase = toastFadingOut
+				t.elapsed = 0
+			}
+		case toastFadingOut:
+			if frac := t.elapsed / toastFadeSeconds; frac >= 1 {
+				t.alpha = 0
+				t.phase = toastDone
+			} else {
+				t.alpha = uint8((1 - frac) * float32(t.opts.BgColor.A))
+			}
+		}
+		t.Element.BgColor.A = t.alpha
+
+		if t.phase == toastDone {
+			tm.root.RemoveChild(t.Element)
+			removed = true
+			continue
+		}
+		live = append(live, t)
+	}
+	tm.toasts = live
+	if removed {
+		tm.restack()
+	}
+}
+
+// dismiss skips straight to toastDone, so the next Update tears it down
+// immediately instead of waiting out its remaining hold/fade-out time.
+func (tm *ToastManager) dismiss(t *Toast) {
+	t.phase = toastDone
+}
+
+// restack recomputes OffsetY for every active toast sharing a Position so
+// they stack outward from their shared edge/center instead of overlapping.
+func (tm *ToastManager) restack() {
+	offsets := map[string]float32{}
+	for _, t := range tm.toasts {
+		t.Element.OffsetY = offsets[t.opts.Position]
+		offsets[t.opts.Position] += t.Element.Height + toastSpacing
+	}
+}
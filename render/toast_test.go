@@ -0,0 +1,75 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestShowToastFadesInHoldsThenFadesOutAndRemovesItself(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 400, 300
+
+	tm := NewToastManager(root)
+	toast := tm.ShowToast("Saved", ToastOptions{Duration: 1, BgColor: Color{R: 10, G: 10, B: 10, A: 200}})
+
+	if len(root.Children) != 1 {
+		t.Fatalf("expected toast appended as a child, got %d children", len(root.Children))
+	}
+	if toast.Element.BgColor.A != 0 {
+		t.Fatalf("expected toast to start fully transparent, got alpha=%d", toast.Element.BgColor.A)
+	}
+
+	tm.Update(toastFadeSeconds)
+	if toast.Element.BgColor.A != 200 {
+		t.Fatalf("expected full alpha after fade-in, got %d", toast.Element.BgColor.A)
+	}
+
+	tm.Update(1)
+	if toast.phase != toastFadingOut {
+		t.Fatalf("expected toast to start fading out after its hold duration, phase=%v", toast.phase)
+	}
+
+	tm.Update(toastFadeSeconds)
+	if len(root.Children) != 0 {
+		t.Fatalf("expected toast to remove itself after fading out, got %d children", len(root.Children))
+	}
+	if len(tm.toasts) != 0 {
+		t.Fatalf("expected manager to drop the finished toast, got %d tracked", len(tm.toasts))
+	}
+}
+
+func TestConcurrentToastsInSamePositionStackWithSpacing(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 400, 300
+
+	tm := NewToastManager(root)
+	first := tm.ShowToast("First", ToastOptions{Position: AnchorBottomRight})
+	second := tm.ShowToast("Second", ToastOptions{Position: AnchorBottomRight})
+
+	if first.Element.OffsetY != 0 {
+		t.Fatalf("expected first toast at offset 0, got %v", first.Element.OffsetY)
+	}
+	want := first.Element.Height + toastSpacing
+	if second.Element.OffsetY != want {
+		t.Fatalf("expected second toast stacked at %v, got %v", want, second.Element.OffsetY)
+	}
+}
+
+func TestClickingAToastDismissesItImmediately(t *testing.T) {
+	doc := &krb.Document{}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Width, root.Height = 400, 300
+
+	tm := NewToastManager(root)
+	toast := tm.ShowToast("Dismiss me", ToastOptions{})
+
+	DispatchButton(toast.Element, krb.EventTypeClick, 0, 0, MouseButtonLeft)
+	tm.Update(0)
+
+	if len(root.Children) != 0 {
+		t.Fatalf("expected clicked toast to be torn down, got %d children", len(root.Children))
+	}
+}
@@ -0,0 +1,18 @@
+package render
+
+// DefaultTooltipDelay is how long an element must be continuously
+// hovered, in seconds, before TooltipTarget starts reporting it.
+const DefaultTooltipDelay float32 = 0.6
+
+// TooltipTarget reports the element a backend should currently show a
+// tooltip for, from d's hover state: the continuously hovered element,
+// once it's been hovered past DefaultTooltipDelay and declares a
+// non-empty Tooltip. ok is false otherwise (nothing hovered, hover too
+// recent, or the hovered element has no tooltip text), in which case a
+// backend should draw no tooltip this frame.
+func TooltipTarget(d *InputDispatcher) (el *RenderElement, text string, ok bool) {
+	if d.Hovered == nil || d.Hovered.Tooltip == "" || d.HoverElapsed < DefaultTooltipDelay {
+		return nil, "", false
+	}
+	return d.Hovered, d.Hovered.Tooltip, true
+}
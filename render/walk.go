@@ -0,0 +1,71 @@
+package render
+
+// Walk visits el and its descendants in pre-order, calling fn on each.
+// If fn returns false for a node, Walk skips that node's children but
+// continues with its siblings.
+func Walk(el *RenderElement, fn func(*RenderElement) bool) {
+	if el == nil {
+		return
+	}
+	if !fn(el) {
+		return
+	}
+	for _, c := range el.Children {
+		Walk(c, fn)
+	}
+}
+
+// Find returns the first element in el's subtree (including el itself)
+// for which match returns true, or nil if none matches.
+func Find(el *RenderElement, match func(*RenderElement) bool) *RenderElement {
+	var found *RenderElement
+	Walk(el, func(e *RenderElement) bool {
+		if found != nil {
+			return false
+		}
+		if match(e) {
+			found = e
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// FindByID returns the first element with the given ID in el's subtree,
+// or nil if none matches.
+func FindByID(el *RenderElement, id string) *RenderElement {
+	return Find(el, func(e *RenderElement) bool { return e.ID == id })
+}
+
+// RenderTree flattens root's subtree (including root itself) into a
+// slice in pre-order: a node always precedes its own descendants, and
+// siblings appear in the same order as el.Children (which in turn
+// matches the KRB element's child reference order, or the order children
+// were appended during component expansion). Two calls against the same,
+// unmodified tree always produce the same slice -- callers may rely on
+// this ordering to diff two snapshots or drive deterministic exports
+// (see accessibility.go, which walks in the same order).
+//
+// The result is cached on root and reused as-is until something in the
+// subtree calls MarkDirty (every InsertChildAt/RemoveChild/MoveChild
+// does), so repeatedly calling RenderTree once per frame on an otherwise
+// static tree costs nothing after the first call. Callers must treat the
+// returned slice as read-only -- sort a copy, not this one, or the next
+// cache hit hands back the reordered result.
+func RenderTree(root *RenderElement) []*RenderElement {
+	if root == nil {
+		return nil
+	}
+	if root.flatCache != nil && root.flatCacheVersion == root.treeVersion {
+		return root.flatCache
+	}
+	var nodes []*RenderElement
+	Walk(root, func(e *RenderElement) bool {
+		nodes = append(nodes, e)
+		return true
+	})
+	root.flatCache = nodes
+	root.flatCacheVersion = root.treeVersion
+	return nodes
+}
@@ -0,0 +1,33 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestRenderTreeIsStablePreOrder(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "root", "a", "b", "a1"}}
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, ID: 1})
+	a := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, ID: 2})
+	b := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, ID: 3})
+	a1 := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer, ID: 4})
+
+	a.Children = []*RenderElement{a1}
+	a1.Parent = a
+	root.Children = []*RenderElement{a, b}
+	a.Parent, b.Parent = root, root
+
+	want := []string{"root", "a", "a1", "b"}
+	for i := 0; i < 3; i++ {
+		got := RenderTree(root)
+		if len(got) != len(want) {
+			t.Fatalf("RenderTree() len = %d, want %d", len(got), len(want))
+		}
+		for j, e := range got {
+			if e.ID != want[j] {
+				t.Fatalf("RenderTree()[%d] = %q, want %q (run %d)", j, e.ID, want[j], i)
+			}
+		}
+	}
+}
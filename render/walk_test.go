@@ -0,0 +1,23 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestFindByID(t *testing.T) {
+	doc := &krb.Document{Strings: []string{"", "target"}}
+	child := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeText, ID: 1})
+	root := newRenderElement(doc, &krb.Element{Type: krb.ElemTypeContainer})
+	root.Children = []*RenderElement{child}
+	child.Parent = root
+
+	found := FindByID(root, "target")
+	if found != child {
+		t.Fatalf("FindByID did not locate the child element")
+	}
+	if FindByID(root, "missing") != nil {
+		t.Fatalf("FindByID should return nil for an unknown id")
+	}
+}
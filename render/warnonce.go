@@ -0,0 +1,29 @@
+package render
+
+import "log"
+
+// warnedOnce records which warnOnce keys have already fired, so a
+// misconfigured KRB file (an unregistered handler, a bad palette index,
+// ...) logs once instead of flooding the log every frame it's hit.
+var warnedOnce = map[string]bool{}
+
+// warnOnce logs format/args via log.Printf the first time it's called
+// with a given key, and is silent on every subsequent call with that same
+// key until ResetWarnings clears the dedup set. Where a future
+// diagnostics system exists, hot paths like this should feed it instead
+// of the global logger.
+func warnOnce(key, format string, args ...any) {
+	if warnedOnce[key] {
+		return
+	}
+	warnedOnce[key] = true
+	log.Printf(format, args...)
+}
+
+// ResetWarnings clears warnOnce's dedup set. Call it after loading a new
+// document so warnings relevant to it (e.g. about one of its own
+// callback names) aren't suppressed by an unrelated document's earlier
+// warning under the same key.
+func ResetWarnings() {
+	warnedOnce = map[string]bool{}
+}
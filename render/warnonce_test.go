@@ -0,0 +1,63 @@
+package render
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestUnregisteredHandlerWarningFiresOnceAcross100Frames(t *testing.T) {
+	ResetWarnings()
+	defer ResetWarnings()
+
+	var buf bytes.Buffer
+	prevOutput, prevFlags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(prevOutput)
+		log.SetFlags(prevFlags)
+	}()
+
+	doc := &krb.Document{Strings: []string{"", "onMissingClick"}}
+	src := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 1}}}
+	el := newRenderElement(doc, src)
+
+	for frame := 0; frame < 100; frame++ {
+		Dispatch(el, krb.EventTypeClick, 0, 0)
+	}
+
+	got := strings.Count(buf.String(), "onMissingClick")
+	if got != 1 {
+		t.Fatalf("expected the unregistered-handler warning exactly once across 100 frames, got %d: %q", got, buf.String())
+	}
+}
+
+func TestResetWarningsAllowsTheWarningAgain(t *testing.T) {
+	ResetWarnings()
+	defer ResetWarnings()
+
+	var buf bytes.Buffer
+	prevOutput, prevFlags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(prevOutput)
+		log.SetFlags(prevFlags)
+	}()
+
+	doc := &krb.Document{Strings: []string{"", "onMissingClick"}}
+	src := &krb.Element{Type: krb.ElemTypeButton, Events: []krb.Event{{Type: krb.EventTypeClick, CallbackID: 1}}}
+	el := newRenderElement(doc, src)
+
+	Dispatch(el, krb.EventTypeClick, 0, 0)
+	ResetWarnings()
+	Dispatch(el, krb.EventTypeClick, 0, 0)
+
+	if got := strings.Count(buf.String(), "onMissingClick"); got != 2 {
+		t.Fatalf("expected the warning again after ResetWarnings, got %d occurrences: %q", got, buf.String())
+	}
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestWindowConfigAppliesDefaultsAndAppOverrides(t *testing.T) {
+	doc := &krb.Document{
+		Header:  krb.Header{Flags: krb.FlagHasApp},
+		Strings: []string{""},
+		Elements: []*krb.Element{
+			{Type: krb.ElemTypeApp, Index: 0},
+		},
+	}
+
+	_, cfg, err := PrepareTree(doc)
+	if err != nil {
+		t.Fatalf("PrepareTree: %v", err)
+	}
+	want := DefaultWindowConfig()
+	if cfg.DefaultFontSize != want.DefaultFontSize || cfg.DefaultBgColor != want.DefaultBgColor || cfg.DefaultFgColor != want.DefaultFgColor {
+		t.Fatalf("expected App with no overrides to keep DefaultWindowConfig values, got %+v", cfg)
+	}
+
+	doc.Elements[0].Properties = []krb.Property{
+		{ID: krb.PropIDFontSize, Type: krb.ValTypeShort, Value: []byte{24, 0}},
+		{ID: krb.PropIDBackgroundColor, Type: krb.ValTypeColor, Value: []byte{9}}, // palette 9 = red
+	}
+	_, cfg, err = PrepareTree(doc)
+	if err != nil {
+		t.Fatalf("PrepareTree: %v", err)
+	}
+	if cfg.DefaultFontSize != 24 {
+		t.Fatalf("DefaultFontSize = %v, want 24 (overridden by App)", cfg.DefaultFontSize)
+	}
+	if cfg.DefaultBgColor != Palette[9] {
+		t.Fatalf("DefaultBgColor = %+v, want %+v (overridden by App)", cfg.DefaultBgColor, Palette[9])
+	}
+	if cfg.DefaultFgColor != want.DefaultFgColor {
+		t.Fatalf("DefaultFgColor changed despite no App override: %+v", cfg.DefaultFgColor)
+	}
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+func TestWindowConfigReadsOverlayCustomProperties(t *testing.T) {
+	doc := &krb.Document{
+		Header:  krb.Header{Flags: krb.FlagHasApp},
+		Strings: []string{"", "borderless", "always_on_top"},
+		Elements: []*krb.Element{
+			{
+				Type:  krb.ElemTypeApp,
+				Index: 0,
+				CustomProperties: []krb.CustomProperty{
+					{KeyIndex: 1, Value: []byte{1}},
+					{KeyIndex: 2, Value: []byte{1}},
+				},
+			},
+		},
+	}
+
+	_, cfg, err := PrepareTree(doc)
+	if err != nil {
+		t.Fatalf("PrepareTree: %v", err)
+	}
+	if !cfg.Borderless || !cfg.AlwaysOnTop {
+		t.Fatalf("expected borderless+always_on_top overlay config, got %+v", cfg)
+	}
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/waozixyz/kryon/krb"
+)
+
+// buildDocWithTwoApps assembles a document declaring two independent App
+// elements, each with one child button, plus an unrelated parentless
+// container that isn't an App element at all.
+func buildDocWithTwoApps() *krb.Document {
+	doc := &krb.Document{
+		Header:  krb.Header{Flags: krb.FlagHasApp},
+		Strings: []string{"", "main", "settings", "btn1", "btn2"},
+	}
+
+	app1 := &krb.Element{Type: krb.ElemTypeApp, ID: 1, Index: 0, Width: 800, Height: 600, Children: []int{1}}
+	btn1 := &krb.Element{Type: krb.ElemTypeButton, ID: 3, Index: 1}
+	app2 := &krb.Element{Type: krb.ElemTypeApp, ID: 2, Index: 2, Width: 400, Height: 300, Children: []int{3}}
+	btn2 := &krb.Element{Type: krb.ElemTypeButton, ID: 4, Index: 3}
+	orphan := &krb.Element{Type: krb.ElemTypeContainer, Index: 4}
+
+	doc.Elements = []*krb.Element{app1, btn1, app2, btn2, orphan}
+	return doc
+}
+
+func TestPrepareWindowsReturnsOneWindowPerApp(t *testing.T) {
+	doc := buildDocWithTwoApps()
+
+	windows, err := PrepareWindows(doc)
+	if err != nil {
+		t.Fatalf("PrepareWindows: %v", err)
+	}
+	if len(windows) != 3 {
+		t.Fatalf("expected 2 App windows + 1 orphan window, got %d", len(windows))
+	}
+
+	if windows[0].Root.ID != "main" || windows[0].Config.Width != 800 {
+		t.Fatalf("window 0 = %+v, want App %q at width 800", windows[0], "main")
+	}
+	if windows[1].Root.ID != "settings" || windows[1].Config.Width != 400 {
+		t.Fatalf("window 1 = %+v, want App %q at width 400", windows[1], "settings")
+	}
+	if windows[2].Root.Type != krb.ElemTypeContainer || !reflect.DeepEqual(windows[2].Config, WindowConfig{}) {
+		t.Fatalf("window 2 = %+v, want zero-config orphan container", windows[2])
+	}
+
+	if len(windows[0].Root.Children) != 1 || windows[0].Root.Children[0].ID != "btn1" {
+		t.Fatalf("first App window missing its child button")
+	}
+	if len(windows[1].Root.Children) != 1 || windows[1].Root.Children[0].ID != "btn2" {
+		t.Fatalf("second App window missing its child button")
+	}
+}